@@ -46,3 +46,9 @@ func (a Struct) String() string {
 	sort.Strings(letters)
 	return strings.Join(letters, "")
 }
+
+// letterSet is the sorted, deduplicated letters of s
+func letterSet(s string) string {
+	set := New(s)
+	return set.String()
+}
@@ -55,3 +55,81 @@ func (*DnaIupac) Complement(c string) string {
 		return "X"
 	}
 }
+
+// Expand produces the nucleotides, in sorted order, that an IUPAC DNA letter stands in for.
+// The gap and any unknown letter stand in for no nucleotides so produce "".
+func (*DnaIupac) Expand(c string) string {
+	switch c {
+	case "A", "C", "G", "T":
+		return c
+
+	case "R":
+		return "AG"
+	case "Y":
+		return "CT"
+	case "S":
+		return "CG"
+	case "W":
+		return "AT"
+	case "K":
+		return "GT"
+	case "M":
+		return "AC"
+
+	case "B":
+		return "CGT"
+	case "D":
+		return "AGT"
+	case "H":
+		return "ACT"
+	case "V":
+		return "ACG"
+
+	case "N":
+		return "ACGT"
+
+	default:
+		return ""
+	}
+}
+
+// Collapse produces the single IUPAC DNA letter that stands in for every given nucleotide.
+// Order and repetition of the nucleotides do not matter; no nucleotides collapse to the gap.
+// If any letter is not a nucleotide the result is the "X" placeholder.
+func (*DnaIupac) Collapse(cs string) string {
+	switch letterSet(cs) {
+	case "":
+		return "-"
+
+	case "A", "C", "G", "T":
+		return letterSet(cs)
+
+	case "AG":
+		return "R"
+	case "CT":
+		return "Y"
+	case "CG":
+		return "S"
+	case "AT":
+		return "W"
+	case "GT":
+		return "K"
+	case "AC":
+		return "M"
+
+	case "CGT":
+		return "B"
+	case "AGT":
+		return "D"
+	case "ACT":
+		return "H"
+	case "ACG":
+		return "V"
+
+	case "ACGT":
+		return "N"
+
+	default:
+		return "X"
+	}
+}
@@ -68,6 +68,29 @@ func TestDnaIupacIsReversible(t *testing.T) {
 	})
 }
 
+// TestDnaIupacCollapsesExpansion checks that the collapse of an expansion is
+// the original
+func TestDnaIupacCollapsesExpansion(t *testing.T) {
+	a := hashmap.NewDnaIupac()
+	for _, c := range alphabet.DnaIupacLetters {
+		exp := a.Expand(string(c))
+		if got := a.Collapse(exp); got != string(c) {
+			t.Errorf("Collapse(Expand(%q)) = %q; Want: %q", c, got, string(c))
+		}
+	}
+}
+
+// TestDnaIupacCollapseReturnsX checks that collapsing an unknown nucleotide results in "X" placeholder
+func TestDnaIupacCollapseReturnsX(t *testing.T) {
+	a := hashmap.NewDnaIupac()
+	notLetters := alphabet.TestExcludesSingleLetters([]byte("ACGT"))
+	for _, c := range notLetters {
+		if got := a.Collapse("A" + string(c)); got != "X" {
+			t.Errorf("Want: %q, Got: %q", "X", got)
+		}
+	}
+}
+
 func ExampleNewDnaIupac() {
 	a := hashmap.NewDnaIupac()
 	fmt.Println(a)
@@ -96,6 +119,20 @@ func ExampleDnaIupac_Complement() {
 	// N
 }
 
+func ExampleDnaIupac_Expand() {
+	a := hashmap.NewDnaIupac()
+	fmt.Println(a.Expand("R"), a.Expand("N"))
+	// Output:
+	// AG ACGT
+}
+
+func ExampleDnaIupac_Collapse() {
+	a := hashmap.NewDnaIupac()
+	fmt.Println(a.Collapse("GA"), a.Collapse("CT"))
+	// Output:
+	// R Y
+}
+
 // BenchmarkCompDnaIupac benchmarks the complement of each possible input byte
 func BenchmarkCompDnaIupac(b *testing.B) {
 	a := hashmap.NewDnaIupac()
@@ -55,3 +55,81 @@ func (*RnaIupac) Complement(c string) string {
 		return "X"
 	}
 }
+
+// Expand produces the nucleotides, in sorted order, that an IUPAC RNA letter stands in for.
+// The gap and any unknown letter stand in for no nucleotides so produce "".
+func (*RnaIupac) Expand(c string) string {
+	switch c {
+	case "A", "C", "G", "U":
+		return c
+
+	case "R":
+		return "AG"
+	case "Y":
+		return "CU"
+	case "S":
+		return "CG"
+	case "W":
+		return "AU"
+	case "K":
+		return "GU"
+	case "M":
+		return "AC"
+
+	case "B":
+		return "CGU"
+	case "D":
+		return "AGU"
+	case "H":
+		return "ACU"
+	case "V":
+		return "ACG"
+
+	case "N":
+		return "ACGU"
+
+	default:
+		return ""
+	}
+}
+
+// Collapse produces the single IUPAC RNA letter that stands in for every given nucleotide.
+// Order and repetition of the nucleotides do not matter; no nucleotides collapse to the gap.
+// If any letter is not a nucleotide the result is the "X" placeholder.
+func (*RnaIupac) Collapse(cs string) string {
+	switch letterSet(cs) {
+	case "":
+		return "-"
+
+	case "A", "C", "G", "U":
+		return letterSet(cs)
+
+	case "AG":
+		return "R"
+	case "CU":
+		return "Y"
+	case "CG":
+		return "S"
+	case "AU":
+		return "W"
+	case "GU":
+		return "K"
+	case "AC":
+		return "M"
+
+	case "CGU":
+		return "B"
+	case "AGU":
+		return "D"
+	case "ACU":
+		return "H"
+	case "ACG":
+		return "V"
+
+	case "ACGU":
+		return "N"
+
+	default:
+		return "X"
+	}
+}
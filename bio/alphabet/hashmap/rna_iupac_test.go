@@ -68,6 +68,29 @@ func TestRnaIupacIsReversible(t *testing.T) {
 	})
 }
 
+// TestRnaIupacCollapsesExpansion checks that the collapse of an expansion is
+// the original
+func TestRnaIupacCollapsesExpansion(t *testing.T) {
+	a := hashmap.NewRnaIupac()
+	for _, c := range alphabet.RnaIupacLetters {
+		exp := a.Expand(string(c))
+		if got := a.Collapse(exp); got != string(c) {
+			t.Errorf("Collapse(Expand(%q)) = %q; Want: %q", c, got, string(c))
+		}
+	}
+}
+
+// TestRnaIupacCollapseReturnsX checks that collapsing an unknown nucleotide results in "X" placeholder
+func TestRnaIupacCollapseReturnsX(t *testing.T) {
+	a := hashmap.NewRnaIupac()
+	notLetters := alphabet.TestExcludesSingleLetters([]byte("ACGU"))
+	for _, c := range notLetters {
+		if got := a.Collapse("A" + string(c)); got != "X" {
+			t.Errorf("Want: %q, Got: %q", "X", got)
+		}
+	}
+}
+
 func ExampleNewRnaIupac() {
 	a := hashmap.NewRnaIupac()
 	fmt.Println(a)
@@ -96,6 +119,20 @@ func ExampleRnaIupac_Complement() {
 	// N
 }
 
+func ExampleRnaIupac_Expand() {
+	a := hashmap.NewRnaIupac()
+	fmt.Println(a.Expand("R"), a.Expand("N"))
+	// Output:
+	// AG ACGU
+}
+
+func ExampleRnaIupac_Collapse() {
+	a := hashmap.NewRnaIupac()
+	fmt.Println(a.Collapse("GA"), a.Collapse("CU"))
+	// Output:
+	// R Y
+}
+
 // BenchmarkCompRnaIupac benchmarks the complement of each possible input byte
 func BenchmarkCompRnaIupac(b *testing.B) {
 	a := hashmap.NewRnaIupac()
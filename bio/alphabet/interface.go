@@ -19,3 +19,13 @@ type Interface interface {
 type Complementer interface {
 	Complement(string) string
 }
+
+// Expander is any alphabet that has letters standing in for several letters
+type Expander interface {
+	Expand(string) string
+}
+
+// Collapser is any alphabet that has letters able to stand in for several letters
+type Collapser interface {
+	Collapse(string) string
+}
@@ -0,0 +1,52 @@
+package codon
+
+import (
+	"github.com/sembio/go/bio/alphabet/hashmap"
+)
+
+// Triplets lists all 64 codons in sorted order
+func Triplets() []string {
+	const bases = "ACGT"
+	cdns := make([]string, 0, 64)
+	for i := range bases {
+		for j := range bases {
+			for k := range bases {
+				cdns = append(cdns, string([]byte{bases[i], bases[j], bases[k]}))
+			}
+		}
+	}
+	return cdns
+}
+
+// Codons lists, in sorted order, the codons which translate to the amino acid aa.
+// An amino acid not found in the table has no codons.
+func Codons(t Translater, aa byte) []string {
+	cdns := make([]string, 0)
+	for _, c := range Triplets() {
+		if got, ok := t.Translate(c); ok && got == aa {
+			cdns = append(cdns, c)
+		}
+	}
+	return cdns
+}
+
+// Ambiguous is the IUPAC codon covering every codon which translates to the amino acid aa
+// (e.g., 'M' is "ATG" and 'L' is "YTN" in the standard table) and whether aa was found.
+// Each position is collapsed separately so the IUPAC codon can cover more than
+// the synonymous codons (e.g., 'S' is "WSN" which also covers "ACN" and "TGN").
+func Ambiguous(t Translater, aa byte) (string, bool) {
+	cdns := Codons(t, aa)
+	if len(cdns) == 0 {
+		return "", false
+	}
+	a := hashmap.NewDnaIupac()
+	amb := make([]byte, 3)
+	for i := range amb {
+		pos := make([]byte, len(cdns))
+		for j, c := range cdns {
+			pos[j] = c[i]
+		}
+		amb[i] = a.Collapse(string(pos))[0]
+	}
+	return string(amb), true
+}
@@ -0,0 +1,87 @@
+package codon_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sembio/go/bio/data/codon"
+)
+
+func TestTriplets(t *testing.T) {
+	cdns := codon.Triplets()
+	if len(cdns) != 64 {
+		t.Errorf("Want: %d, Got: %d", 64, len(cdns))
+	}
+	seen := make(map[string]struct{}, len(cdns))
+	for _, c := range cdns {
+		if _, ok := seen[c]; ok {
+			t.Errorf("%q listed more than once", c)
+		}
+		seen[c] = struct{}{}
+	}
+}
+
+func TestCodonsTranslate(t *testing.T) {
+	tables := []codon.Interface{
+		codon.Standard{},
+		codon.VertebrateMt{},
+		codon.InvertebrateMt{},
+		codon.AltYeast{},
+	}
+	for _, table := range tables {
+		t.Run(fmt.Sprint(table), func(t *testing.T) {
+			for _, c := range codon.Triplets() {
+				aa, ok := table.Translate(c)
+				if !ok {
+					continue
+				}
+				found := false
+				for _, syn := range codon.Codons(table, aa) {
+					if syn == c {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%q is missing from codons for %q", c, aa)
+				}
+			}
+		})
+	}
+}
+
+func TestAmbiguous(t *testing.T) {
+	tt := []struct {
+		table codon.Interface
+		aa    byte
+		want  string
+		ok    bool
+	}{
+		{codon.Standard{}, 'M', "ATG", true},
+		{codon.Standard{}, 'L', "YTN", true},
+		{codon.Standard{}, 'S', "WSN", true},
+		{codon.Standard{}, 'R', "MGN", true},
+		{codon.VertebrateMt{}, 'M', "ATR", true},
+		{codon.VertebrateMt{}, 'W', "TGR", true},
+		{codon.Standard{}, 'X', "", false},
+	}
+	for _, tc := range tt {
+		t.Run(fmt.Sprintf("%s %q", tc.table, tc.aa), func(t *testing.T) {
+			got, ok := codon.Ambiguous(tc.table, tc.aa)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("Want: %q %v, Got: %q %v", tc.want, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func ExampleCodons() {
+	fmt.Println(codon.Codons(codon.Standard{}, 'L'))
+	// Output:
+	// [CTA CTC CTG CTT TTA TTG]
+}
+
+func ExampleAmbiguous() {
+	fmt.Println(codon.Ambiguous(codon.Standard{}, 'L'))
+	// Output:
+	// YTN true
+}
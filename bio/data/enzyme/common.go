@@ -0,0 +1,74 @@
+package enzyme
+
+import (
+	"sort"
+)
+
+// common are the built in enzymes, sorted by name with their isoschizomers filled in
+var common = func() []Enzyme {
+	es := []Enzyme{
+		{Name: "Acc65I", Site: "GGTACC", Cuts: []Cut{{1, 5}}},
+		{Name: "AgeI", Site: "ACCGGT", Cuts: []Cut{{1, 5}}},
+		{Name: "AluI", Site: "AGCT", Cuts: []Cut{{2, 2}}},
+		{Name: "ApaI", Site: "GGGCCC", Cuts: []Cut{{5, 1}}},
+		{Name: "AscI", Site: "GGCGCGCC", Cuts: []Cut{{2, 6}}},
+		{Name: "AvrII", Site: "CCTAGG", Cuts: []Cut{{1, 5}}},
+		{Name: "BamHI", Site: "GGATCC", Cuts: []Cut{{1, 5}}},
+		{Name: "BbsI", Site: "GAAGAC", Cuts: []Cut{{8, 12}}},
+		{Name: "BglII", Site: "AGATCT", Cuts: []Cut{{1, 5}}},
+		{Name: "BmtI", Site: "GCTAGC", Cuts: []Cut{{5, 1}}},
+		{Name: "BsaI", Site: "GGTCTC", Cuts: []Cut{{7, 11}}},
+		{Name: "BsiWI", Site: "CGTACG", Cuts: []Cut{{1, 5}}},
+		{Name: "BsmBI", Site: "CGTCTC", Cuts: []Cut{{7, 11}}},
+		{Name: "BsrGI", Site: "TGTACA", Cuts: []Cut{{1, 5}}},
+		{Name: "ClaI", Site: "ATCGAT", Cuts: []Cut{{2, 4}}},
+		{Name: "DpnI", Site: "GATC", Cuts: []Cut{{2, 2}}},
+		{Name: "DpnII", Site: "GATC", Cuts: []Cut{{0, 4}}},
+		{Name: "DraI", Site: "TTTAAA", Cuts: []Cut{{3, 3}}},
+		{Name: "EagI", Site: "CGGCCG", Cuts: []Cut{{1, 5}}},
+		{Name: "Ecl136II", Site: "GAGCTC", Cuts: []Cut{{3, 3}}},
+		{Name: "EcoRI", Site: "GAATTC", Cuts: []Cut{{1, 5}}},
+		{Name: "EcoRV", Site: "GATATC", Cuts: []Cut{{3, 3}}},
+		{Name: "Esp3I", Site: "CGTCTC", Cuts: []Cut{{7, 11}}},
+		{Name: "FokI", Site: "GGATG", Cuts: []Cut{{14, 18}}},
+		{Name: "HaeIII", Site: "GGCC", Cuts: []Cut{{2, 2}}},
+		{Name: "HindIII", Site: "AAGCTT", Cuts: []Cut{{1, 5}}},
+		{Name: "HinfI", Site: "GANTC", Cuts: []Cut{{1, 4}}},
+		{Name: "HpaI", Site: "GTTAAC", Cuts: []Cut{{3, 3}}},
+		{Name: "HpaII", Site: "CCGG", Cuts: []Cut{{1, 3}}},
+		{Name: "KpnI", Site: "GGTACC", Cuts: []Cut{{5, 1}}},
+		{Name: "MboI", Site: "GATC", Cuts: []Cut{{0, 4}}},
+		{Name: "MluI", Site: "ACGCGT", Cuts: []Cut{{1, 5}}},
+		{Name: "MseI", Site: "TTAA", Cuts: []Cut{{1, 3}}},
+		{Name: "MspI", Site: "CCGG", Cuts: []Cut{{1, 3}}},
+		{Name: "NcoI", Site: "CCATGG", Cuts: []Cut{{1, 5}}},
+		{Name: "NdeI", Site: "CATATG", Cuts: []Cut{{2, 4}}},
+		{Name: "NheI", Site: "GCTAGC", Cuts: []Cut{{1, 5}}},
+		{Name: "NlaIII", Site: "CATG", Cuts: []Cut{{4, 0}}},
+		{Name: "NotI", Site: "GCGGCCGC", Cuts: []Cut{{2, 6}}},
+		{Name: "NsiI", Site: "ATGCAT", Cuts: []Cut{{5, 1}}},
+		{Name: "PacI", Site: "TTAATTAA", Cuts: []Cut{{5, 3}}},
+		{Name: "PaeR7I", Site: "CTCGAG", Cuts: []Cut{{1, 5}}},
+		{Name: "PmeI", Site: "GTTTAAAC", Cuts: []Cut{{4, 4}}},
+		{Name: "PspOMI", Site: "GGGCCC", Cuts: []Cut{{1, 5}}},
+		{Name: "PstI", Site: "CTGCAG", Cuts: []Cut{{5, 1}}},
+		{Name: "PvuII", Site: "CAGCTG", Cuts: []Cut{{3, 3}}},
+		{Name: "SacI", Site: "GAGCTC", Cuts: []Cut{{5, 1}}},
+		{Name: "SalI", Site: "GTCGAC", Cuts: []Cut{{1, 5}}},
+		{Name: "SapI", Site: "GCTCTTC", Cuts: []Cut{{8, 11}}},
+		{Name: "Sau3AI", Site: "GATC", Cuts: []Cut{{0, 4}}},
+		{Name: "ScaI", Site: "AGTACT", Cuts: []Cut{{3, 3}}},
+		{Name: "SfiI", Site: "GGCCNNNNNGGCC", Cuts: []Cut{{8, 5}}},
+		{Name: "SmaI", Site: "CCCGGG", Cuts: []Cut{{3, 3}}},
+		{Name: "SpeI", Site: "ACTAGT", Cuts: []Cut{{1, 5}}},
+		{Name: "SphI", Site: "GCATGC", Cuts: []Cut{{5, 1}}},
+		{Name: "StuI", Site: "AGGCCT", Cuts: []Cut{{3, 3}}},
+		{Name: "SwaI", Site: "ATTTAAAT", Cuts: []Cut{{4, 4}}},
+		{Name: "TaqI", Site: "TCGA", Cuts: []Cut{{1, 3}}},
+		{Name: "XbaI", Site: "TCTAGA", Cuts: []Cut{{1, 5}}},
+		{Name: "XhoI", Site: "CTCGAG", Cuts: []Cut{{1, 5}}},
+		{Name: "XmaI", Site: "CCCGGG", Cuts: []Cut{{1, 5}}},
+	}
+	sort.Slice(es, func(i, j int) bool { return es[i].Name < es[j].Name })
+	return isoschizomers(es)
+}()
@@ -0,0 +1,150 @@
+package enzyme
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sembio/go/bio/search/iupac"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Terminus is an end of a Fragment
+type Terminus struct {
+	Enzyme   string // the enzyme that cut the end, or "" for the end of a linear sequence
+	Overhang Overhang
+	Sequence string // the single stranded bases as read on the top strand
+}
+
+// Fragment is a piece of DNA left by a digest
+type Fragment struct {
+	Start    int // where the top strand starts
+	End      int // where the top strand ends, past the length of a circular sequence for the fragment across its origin
+	Sequence *immutable.Dna
+	Left     Terminus
+	Right    Terminus
+}
+
+// site is where an enzyme cuts a sequence
+type site struct {
+	top, bottom int
+	enzyme      string
+}
+
+// Digest cuts seq with every site of the enzymes on either strand, returning the
+// fragments in order of where they start. Cuts that would fall outside a linear sequence
+// are skipped. An uncut circular sequence is returned whole with no ends.
+func Digest(seq *immutable.Dna, circular bool, enzymes ...Enzyme) ([]Fragment, error) {
+	text, err := seq.Range(0, seq.Length())
+	if err != nil {
+		return nil, err
+	}
+	n := len(text)
+	var sites []site
+	for _, e := range enzymes {
+		if len(e.Cuts) == 0 {
+			return nil, fmt.Errorf("enzyme %s has no known cut", e.Name)
+		}
+		p, err := iupac.New(e.Site)
+		if err != nil {
+			return nil, fmt.Errorf("enzyme %s: %v", e.Name, err)
+		}
+		search := text
+		if circular && n > 0 {
+			for len(search) < n+len(e.Site)-1 {
+				search += text
+			}
+			search = search[:n+len(e.Site)-1]
+		}
+		for _, m := range p.FindString(search) {
+			if m.Start >= n {
+				continue
+			}
+			for _, c := range e.Cuts {
+				s := site{m.Start + c.Top, m.Start + c.Bottom, e.Name}
+				if m.Strand == '-' {
+					s.top, s.bottom = m.End-c.Bottom, m.End-c.Top
+				}
+				if circular {
+					shift := s.top - mod(s.top, n)
+					s.top, s.bottom = s.top-shift, s.bottom-shift
+				} else if s.top <= 0 || s.top >= n || s.bottom <= 0 || s.bottom >= n {
+					continue
+				}
+				sites = append(sites, s)
+			}
+		}
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		a, b := sites[i], sites[j]
+		if a.top != b.top {
+			return a.top < b.top
+		}
+		if a.bottom != b.bottom {
+			return a.bottom < b.bottom
+		}
+		return a.enzyme < b.enzyme
+	})
+	cuts := sites[:0]
+	for i, s := range sites {
+		if i == 0 || s.top != sites[i-1].top || s.bottom != sites[i-1].bottom {
+			cuts = append(cuts, s)
+		}
+	}
+
+	if circular && len(cuts) == 0 {
+		return []Fragment{{Start: 0, End: n, Sequence: seq}}, nil
+	}
+	var bounds []site
+	if !circular {
+		bounds = append(bounds, site{})
+	}
+	bounds = append(bounds, cuts...)
+	if circular {
+		bounds = append(bounds, site{cuts[0].top + n, cuts[0].bottom + n, cuts[0].enzyme})
+	} else {
+		bounds = append(bounds, site{top: n, bottom: n})
+	}
+	frags := make([]Fragment, 0, len(bounds)-1)
+	for i := 1; i < len(bounds); i++ {
+		from, to := bounds[i-1], bounds[i]
+		dna, _ := immutable.NewDna(wrap(text, from.top, to.top))
+		frags = append(frags, Fragment{
+			Start:    from.top,
+			End:      to.top,
+			Sequence: dna,
+			Left:     terminus(text, from),
+			Right:    terminus(text, to),
+		})
+	}
+	return frags, nil
+}
+
+// terminus is the end left by a cut
+func terminus(text string, s site) Terminus {
+	if s.enzyme == "" {
+		return Terminus{}
+	}
+	lo, hi := s.top, s.bottom
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return Terminus{
+		Enzyme:   s.enzyme,
+		Overhang: Cut{s.top, s.bottom}.Overhang(),
+		Sequence: wrap(text, lo, hi),
+	}
+}
+
+// wrap is text from start to end, reading around its origin as a circle
+func wrap(text string, start, end int) string {
+	out := make([]byte, 0, end-start)
+	for i := start; i < end; i++ {
+		out = append(out, text[mod(i, len(text))])
+	}
+	return string(out)
+}
+
+// mod is i modulo n, from 0 to n-1
+func mod(i, n int) int {
+	return ((i % n) + n) % n
+}
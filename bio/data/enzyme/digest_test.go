@@ -0,0 +1,138 @@
+package enzyme_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/data/enzyme"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// lookup finds common enzymes by name
+func lookup(names ...string) []enzyme.Enzyme {
+	var es []enzyme.Enzyme
+	for _, name := range names {
+		e, _ := enzyme.Lookup(name)
+		es = append(es, e)
+	}
+	return es
+}
+
+func TestDigest(t *testing.T) {
+	tt := []struct {
+		name     string
+		seq      string
+		circular bool
+		enzymes  []string
+		want     []string // start-end:sequence left/right overhangs
+	}{
+		{"FivePrime", "AAAGAATTCAAA", false, []string{"EcoRI"},
+			[]string{"0-4:AAAG /5'AATT", "4-12:AATTCAAA 5'AATT/"}},
+		{"ThreePrime", "AAGGTACCAA", false, []string{"KpnI"},
+			[]string{"0-7:AAGGTAC /3'GTAC", "7-10:CAA 3'GTAC/"}},
+		{"Blunt", "AAGATATCAA", false, []string{"EcoRV"},
+			[]string{"0-5:AAGAT /blunt", "5-10:ATCAA blunt/"}},
+		{"ReverseStrand", "TTTTTTTTTTGAGACCAAAA", false, []string{"BsaI"},
+			[]string{"0-5:TTTTT /5'TTTT", "5-20:TTTTTGAGACCAAAA 5'TTTT/"}},
+		{"OutsideLinear", "GGTCTCAAA", false, []string{"BsaI"},
+			[]string{"0-9:GGTCTCAAA /"}},
+		{"TwoEnzymes", "GGATCCAAAGAATTC", false, []string{"EcoRI", "BamHI"},
+			[]string{"0-1:G /5'GATC", "1-10:GATCCAAAG 5'GATC/5'AATT", "10-15:AATTC 5'AATT/"}},
+		{"Isoschizomers", "AAGGATCAA", false, []string{"MboI", "Sau3AI"},
+			[]string{"0-3:AAG /5'GATC", "3-9:GATCAA 5'GATC/"}},
+		{"Circular", "CCCGAATTCCCC", true, []string{"EcoRI"},
+			[]string{"4-16:AATTCCCCCCCG 5'AATT/5'AATT"}},
+		{"AcrossOrigin", "ATTCCCCCGA", true, []string{"EcoRI"},
+			[]string{"9-19:AATTCCCCCG 5'AATT/5'AATT"}},
+		{"Uncut", "CCCCCC", true, []string{"EcoRI"},
+			[]string{"0-6:CCCCCC /"}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			frags, err := enzyme.Digest(immutable.TestDna(tc.seq), tc.circular, lookup(tc.enzymes...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range frags {
+				got = append(got, fmt.Sprintf("%d-%d:%s %s%s/%s%s", f.Start, f.End, f.Sequence.String(),
+					f.Left.Overhang, f.Left.Sequence, f.Right.Overhang, f.Right.Sequence))
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("Want: %v, Got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDigestTiles(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+	common := enzyme.Common()
+
+	properties.Property("Fragments cover the sequence end to end",
+		prop.ForAll(
+			func(seq string, circular bool, seed int64) bool {
+				rng := rand.New(rand.NewSource(seed))
+				es := []enzyme.Enzyme{common[rng.Intn(len(common))], common[rng.Intn(len(common))]}
+				frags, err := enzyme.Digest(immutable.TestDna(seq), circular, es...)
+				if err != nil || len(frags) == 0 {
+					return false
+				}
+				joined := ""
+				for i, f := range frags {
+					next := frags[(i+1)%len(frags)]
+					if !circular && i == len(frags)-1 {
+						next = frags[0]
+						if f.End != len(seq) || next.Start != 0 {
+							return false
+						}
+					} else if f.End%len(seq) != next.Start%len(seq) || f.Right != next.Left {
+						return false
+					}
+					if f.End-f.Start != int(f.Sequence.Length()) {
+						return false
+					}
+					joined += f.Sequence.String()
+				}
+				if circular {
+					return len(joined) == len(seq) && strings.Contains(seq+seq, joined)
+				}
+				return joined == seq
+			},
+			gen.RegexMatch("[ACGT]{20,400}"),
+			gen.Bool(),
+			gen.Int64(),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestDigestErrors(t *testing.T) {
+	if _, err := enzyme.Digest(immutable.TestDna("GATC"), false, enzyme.Enzyme{Name: "Uncut", Site: "GATC"}); err == nil {
+		t.Error("Want: error for an enzyme with no known cut")
+	}
+	if _, err := enzyme.Digest(immutable.TestDna("GATC"), false, enzyme.Enzyme{Name: "Bad", Site: "GA-C", Cuts: []enzyme.Cut{{1, 3}}}); err == nil {
+		t.Error("Want: error for a site that is not IUPAC")
+	}
+}
+
+func ExampleDigest() {
+	ecoRI, _ := enzyme.Lookup("EcoRI")
+	bamHI, _ := enzyme.Lookup("BamHI")
+	plasmid, _ := immutable.NewDna("GAATTCAAAAAAAAAAGGATCCTTTTTTTT")
+
+	frags, _ := enzyme.Digest(plasmid, true, ecoRI, bamHI)
+	for _, f := range frags {
+		fmt.Println(f.Start, f.End, f.Left.Enzyme, f.Right.Enzyme, f.Right.Sequence)
+	}
+	// Output:
+	// 1 17 EcoRI BamHI GATC
+	// 17 31 BamHI EcoRI AATT
+}
@@ -0,0 +1,9 @@
+/*
+Package enzyme contains restriction enzymes and digests DNA with them.
+
+Each Enzyme has a recognition site written with IUPAC letters and the positions where it
+cuts the two strands of its site, from which the overhang it leaves follows. Common
+enzymes are built in and others can be read from REBASE (http://rebase.neb.com) files
+in the withrefm and emboss_e formats.
+*/
+package enzyme
@@ -0,0 +1,101 @@
+package enzyme
+
+import (
+	"sort"
+)
+
+// Overhang is the kind of end a cut leaves
+type Overhang byte
+
+const (
+	// Blunt ends cut both strands at the same place
+	Blunt Overhang = iota + 1
+
+	// FivePrime ends leave the 5' end of the top strand single stranded
+	FivePrime
+
+	// ThreePrime ends leave the 3' end of the bottom strand single stranded
+	ThreePrime
+)
+
+// String is the name of the overhang, or "" for no cut
+func (o Overhang) String() string {
+	switch o {
+	case Blunt:
+		return "blunt"
+	case FivePrime:
+		return "5'"
+	case ThreePrime:
+		return "3'"
+	default:
+		return ""
+	}
+}
+
+// Cut is where an enzyme cuts both strands of DNA, as the number of bases of the top
+// strand of its site before each cut. Cuts before the site are negative and cuts after
+// it are beyond the length of the site.
+type Cut struct {
+	Top    int
+	Bottom int
+}
+
+// Overhang is the kind of end the cut leaves
+func (c Cut) Overhang() Overhang {
+	switch {
+	case c.Top < c.Bottom:
+		return FivePrime
+	case c.Top > c.Bottom:
+		return ThreePrime
+	default:
+		return Blunt
+	}
+}
+
+// Enzyme is a restriction enzyme
+type Enzyme struct {
+	Name          string
+	Site          string // recognition site in IUPAC letters, 5' to 3' on the top strand
+	Cuts          []Cut  // most enzymes cut once; some cut on both sides of their site
+	Isoschizomers []string
+}
+
+// Overhang is the kind of end the enzyme leaves, or 0 if it has no known cut
+func (e Enzyme) Overhang() Overhang {
+	if len(e.Cuts) == 0 {
+		return 0
+	}
+	return e.Cuts[0].Overhang()
+}
+
+// Common are common commercially available restriction enzymes, in order of name
+func Common() []Enzyme {
+	es := make([]Enzyme, len(common))
+	copy(es, common)
+	return es
+}
+
+// Lookup finds a common enzyme by name
+func Lookup(name string) (Enzyme, bool) {
+	i := sort.Search(len(common), func(i int) bool { return common[i].Name >= name })
+	if i < len(common) && common[i].Name == name {
+		return common[i], true
+	}
+	return Enzyme{}, false
+}
+
+// isoschizomers fills in the isoschizomers of enzymes, those with the same site
+func isoschizomers(es []Enzyme) []Enzyme {
+	bySite := make(map[string][]string)
+	for _, e := range es {
+		bySite[e.Site] = append(bySite[e.Site], e.Name)
+	}
+	for i, e := range es {
+		for _, name := range bySite[e.Site] {
+			if name != e.Name {
+				es[i].Isoschizomers = append(es[i].Isoschizomers, name)
+			}
+		}
+	}
+	return es
+}
@@ -0,0 +1,61 @@
+package enzyme_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sembio/go/bio/data/enzyme"
+)
+
+func TestOverhang(t *testing.T) {
+	for name, want := range map[string]enzyme.Overhang{
+		"EcoRI": enzyme.FivePrime,
+		"KpnI":  enzyme.ThreePrime,
+		"EcoRV": enzyme.Blunt,
+		"BsaI":  enzyme.FivePrime,
+		"SfiI":  enzyme.ThreePrime,
+	} {
+		e, ok := enzyme.Lookup(name)
+		if !ok || e.Overhang() != want {
+			t.Errorf("%s Want: %v, Got: %v %v", name, want, e.Overhang(), ok)
+		}
+	}
+	if o := (enzyme.Enzyme{Name: "Unknown", Site: "GATC"}).Overhang(); o != 0 || o.String() != "" {
+		t.Errorf("Want: no overhang for no cut, Got: %v", o)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if _, ok := enzyme.Lookup("NotAnEnzyme"); ok {
+		t.Error("Want: no enzyme")
+	}
+	es := enzyme.Common()
+	if !sort.SliceIsSorted(es, func(i, j int) bool { return es[i].Name < es[j].Name }) {
+		t.Error("Want: common enzymes in order of name")
+	}
+	for _, e := range es {
+		if got, ok := enzyme.Lookup(e.Name); !ok || got.Name != e.Name {
+			t.Errorf("Want: %s, Got: %s %v", e.Name, got.Name, ok)
+		}
+		for _, c := range e.Cuts {
+			if c.Top < 0 || c.Bottom < 0 {
+				t.Errorf("%s Want: cuts from the start of the site, Got: %v", e.Name, c)
+			}
+		}
+	}
+}
+
+func TestIsoschizomers(t *testing.T) {
+	for name, want := range map[string][]string{
+		"MboI":  {"DpnI", "DpnII", "Sau3AI"},
+		"KpnI":  {"Acc65I"},
+		"SmaI":  {"XmaI"},
+		"EcoRI": nil,
+	} {
+		e, _ := enzyme.Lookup(name)
+		if !reflect.DeepEqual(e.Isoschizomers, want) {
+			t.Errorf("%s Want: %v, Got: %v", name, want, e.Isoschizomers)
+		}
+	}
+}
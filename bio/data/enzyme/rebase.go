@@ -0,0 +1,174 @@
+package enzyme
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// iupacLetters are the letters a site may hold
+const iupacLetters = "ACGTRYSWKMBDHVN"
+
+// ReadRebase reads enzymes from a REBASE file in the withrefm format, in which each
+// field of an enzyme is on a line starting with its number, such as "<1>EcoRI". Enzymes
+// with an unknown site ("?") are skipped; enzymes whose cuts are unknown have no Cuts.
+func ReadRebase(r io.Reader) ([]Enzyme, error) {
+	var es []Enzyme
+	var e *Enzyme
+	add := func() error {
+		if e == nil || e.Site == "" || e.Site == "?" {
+			return nil
+		}
+		site, cuts, err := parseSite(e.Site)
+		if err != nil {
+			return fmt.Errorf("enzyme %s: %v", e.Name, err)
+		}
+		e.Site, e.Cuts = site, cuts
+		es = append(es, *e)
+		return nil
+	}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if len(line) < 3 || line[0] != '<' || line[2] != '>' {
+			continue
+		}
+		value := strings.TrimSpace(line[3:])
+		switch line[1] {
+		case '1':
+			if err := add(); err != nil {
+				return nil, err
+			}
+			e = &Enzyme{Name: value}
+		case '2':
+			if e != nil && value != "" {
+				e.Isoschizomers = strings.Split(value, ",")
+			}
+		case '3':
+			if e != nil {
+				e.Site = value
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := add(); err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+// parseSite parses a site in REBASE notation: the top strand cut is marked by ^ within
+// the site, with the bottom strand cut placed symmetrically, or cuts outside the site
+// are given as (top/bottom) bases before or after it
+func parseSite(s string) (string, []Cut, error) {
+	var cuts []Cut
+	var before, after *Cut
+	if strings.HasPrefix(s, "(") {
+		i := strings.IndexByte(s, ')')
+		if i < 0 {
+			return "", nil, fmt.Errorf("unclosed cut in site %q", s)
+		}
+		top, bottom, err := parseCut(s[1:i])
+		if err != nil {
+			return "", nil, err
+		}
+		before = &Cut{-top, -bottom}
+		s = s[i+1:]
+	}
+	if strings.HasSuffix(s, ")") {
+		i := strings.LastIndexByte(s, '(')
+		if i < 0 {
+			return "", nil, fmt.Errorf("unopened cut in site %q", s)
+		}
+		top, bottom, err := parseCut(s[i+1 : len(s)-1])
+		if err != nil {
+			return "", nil, err
+		}
+		s = s[:i]
+		after = &Cut{len(s) + top, len(s) + bottom}
+	}
+	if before != nil {
+		cuts = append(cuts, *before)
+	}
+	if i := strings.IndexByte(s, '^'); i >= 0 {
+		s = s[:i] + s[i+1:]
+		cuts = append(cuts, Cut{i, len(s) - i})
+	}
+	if after != nil {
+		cuts = append(cuts, *after)
+	}
+	s = strings.ToUpper(s)
+	if s == "" || strings.Trim(s, iupacLetters) != "" {
+		return "", nil, fmt.Errorf("site %q is not in IUPAC letters", s)
+	}
+	return s, cuts, nil
+}
+
+// parseCut parses the "top/bottom" offsets of a cut
+func parseCut(s string) (int, int, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("cut %q is not top/bottom", s)
+	}
+	top, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cut %q: %v", s, err)
+	}
+	bottom, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cut %q: %v", s, err)
+	}
+	return top, bottom, nil
+}
+
+// ReadEmboss reads enzymes from a REBASE file in the emboss_e format, with one enzyme
+// per line of name, site, length, number of cuts, bluntness, and up to two pairs of cuts.
+// EMBOSS counts cuts before the site from -1, so they are shifted to count from 0 as Cut
+// does. Isoschizomers are the other enzymes in the file with the same site.
+func ReadEmboss(r io.Reader) ([]Enzyme, error) {
+	var es []Enzyme
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("line %d has %d fields, not 9", line, len(fields))
+		}
+		nums := make([]int, 7)
+		for i := range nums {
+			n, err := strconv.Atoi(fields[i+2])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			if n < 0 {
+				n++
+			}
+			nums[i] = n
+		}
+		e := Enzyme{Name: fields[0], Site: strings.ToUpper(fields[1])}
+		if strings.Trim(e.Site, iupacLetters) != "" || len(e.Site) != nums[0] {
+			return nil, fmt.Errorf("line %d: site %q is not %d IUPAC letters", line, fields[1], nums[0])
+		}
+		switch nums[1] {
+		case 0:
+		case 2:
+			e.Cuts = []Cut{{nums[3], nums[4]}}
+		case 4:
+			e.Cuts = []Cut{{nums[3], nums[4]}, {nums[5], nums[6]}}
+		default:
+			return nil, fmt.Errorf("line %d: %d cuts is not 0, 2, or 4", line, nums[1])
+		}
+		es = append(es, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return isoschizomers(es), nil
+}
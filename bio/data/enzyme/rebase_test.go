@@ -0,0 +1,100 @@
+package enzyme_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/data/enzyme"
+)
+
+const withrefm = `
+REBASE, The Restriction Enzyme Database   http://rebase.neb.com
+Copyright (c)  Dr. Richard J. Roberts, 2024.   All rights reserved.
+
+<1>AjuI
+<2>
+<3>(7/12)GAANNNNNNNTTGG(11/6)
+<4>
+<5>Acinetobacter junii
+<6>RFL49
+<7>B
+<8>
+
+<1>EcoRI
+<2>
+<3>G^AATTC
+<4>2(6)
+<5>Escherichia coli RY13
+<6>R.N. Yoshimori
+<7>ABCFGHIJKMNOQRSVXY
+<8>
+
+<1>FokI
+<2>BstF5I,BtsCI
+<3>GGATG(9/13)
+<4>
+<5>Flavobacterium okeanokoites
+<6>ATCC 33513
+<7>IJMNRV
+<8>
+
+<1>Unknown
+<2>
+<3>?
+<4>
+
+<1>Uncut
+<2>
+<3>GATCC
+<4>
+`
+
+const emboss = `# REBASE version 401                                              emboss_e.401
+#
+# name<ws>pattern<ws>len<ws>ncuts<ws>blunt<ws>c1<ws>c2<ws>c3<ws>c4
+AjuI	GAANNNNNNNTTGG	14	4	0	-8	-13	25	20
+EcoRI	GAATTC	6	2	0	1	5	0	0
+EcoRV	GATATC	6	2	1	3	3	0	0
+FokI	GGATG	5	2	0	14	18	0	0
+BstF5I	GGATG	5	2	0	7	5	0	0
+Uncut	GATCC	5	0	0	0	0	0	0
+`
+
+func TestReadRebase(t *testing.T) {
+	got, err := enzyme.ReadRebase(strings.NewReader(withrefm))
+	want := []enzyme.Enzyme{
+		{Name: "AjuI", Site: "GAANNNNNNNTTGG", Cuts: []enzyme.Cut{{-7, -12}, {25, 20}}},
+		{Name: "EcoRI", Site: "GAATTC", Cuts: []enzyme.Cut{{1, 5}}},
+		{Name: "FokI", Site: "GGATG", Cuts: []enzyme.Cut{{14, 18}}, Isoschizomers: []string{"BstF5I", "BtsCI"}},
+		{Name: "Uncut", Site: "GATCC"},
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, got, err)
+	}
+	for _, bad := range []string{"<1>Bad\n<3>GAXTC\n", "<1>Bad\n<3>GATC(1/x)\n", "<1>Bad\n<3>(1/2GATC\n", "<1>Bad\n<3>GATC(1)\n"} {
+		if _, err := enzyme.ReadRebase(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q Want: error", bad)
+		}
+	}
+}
+
+func TestReadEmboss(t *testing.T) {
+	got, err := enzyme.ReadEmboss(strings.NewReader(emboss))
+	want := []enzyme.Enzyme{
+		{Name: "AjuI", Site: "GAANNNNNNNTTGG", Cuts: []enzyme.Cut{{-7, -12}, {25, 20}}},
+		{Name: "EcoRI", Site: "GAATTC", Cuts: []enzyme.Cut{{1, 5}}},
+		{Name: "EcoRV", Site: "GATATC", Cuts: []enzyme.Cut{{3, 3}}},
+		{Name: "FokI", Site: "GGATG", Cuts: []enzyme.Cut{{14, 18}}, Isoschizomers: []string{"BstF5I"}},
+		{Name: "BstF5I", Site: "GGATG", Cuts: []enzyme.Cut{{7, 5}}, Isoschizomers: []string{"FokI"}},
+		{Name: "Uncut", Site: "GATCC"},
+	}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, got, err)
+	}
+	for _, bad := range []string{"EcoRI\tGAATTC\t6\n", "EcoRI\tGAATTC\t5\t2\t0\t1\t5\t0\t0\n", "EcoRI\tGAATTC\t6\t3\t0\t1\t5\t0\t0\n", "EcoRI\tGAATTC\t6\tx\t0\t1\t5\t0\t0\n"} {
+		if _, err := enzyme.ReadEmboss(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q Want: error", bad)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package quality
+
+import (
+	"fmt"
+)
+
+// Encoding is a scheme for writing quality scores as single ASCII characters
+type Encoding int
+
+const (
+	// Sanger is Phred+33 with scores from 0 to 40
+	Sanger Encoding = iota
+
+	// Illumina13 is Illumina v1.3 Phred+64 with scores from 0 to 40
+	Illumina13
+
+	// Illumina15 is Illumina v1.5 Phred+64 with scores from 2 to 41
+	Illumina15
+
+	// Illumina18 is Illumina v1.8+ Phred+33 with scores from 0 to 41
+	Illumina18
+
+	// Solexa is Solexa+64 with Solexa scores from -5 to 40
+	Solexa
+)
+
+// Encodings lists every known Encoding
+func Encodings() []Encoding {
+	return []Encoding{Sanger, Illumina13, Illumina15, Illumina18, Solexa}
+}
+
+// String provides a human-readable name of the Encoding
+func (e Encoding) String() string {
+	switch e {
+	case Sanger:
+		return "Sanger Phred+33"
+	case Illumina13:
+		return "Illumina+64 (v1.3+)"
+	case Illumina15:
+		return "Illumina+64 (v1.5+)"
+	case Illumina18:
+		return "Illumina Phred+33 (v1.8+)"
+	case Solexa:
+		return "Solexa+64"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int(e))
+	}
+}
+
+// Min is the ASCII character with the lowest quality score
+func (e Encoding) Min() byte {
+	switch e {
+	case Illumina13:
+		return MinIllumina64V13
+	case Illumina15:
+		return MinIllumina64V15
+	case Solexa:
+		return MinSolexa64
+	default:
+		return MinPhred33
+	}
+}
+
+// Max is the ASCII character with the highest quality score
+func (e Encoding) Max() byte {
+	switch e {
+	case Illumina13:
+		return MaxIllumina64V13
+	case Illumina15:
+		return MaxIllumina64V15
+	case Illumina18:
+		return MaxIlluminaPhred33
+	case Solexa:
+		return MaxSolexa64
+	default:
+		return MaxSangerPhred33
+	}
+}
+
+// Decode takes the single-byte ASCII character used to represent
+// quality score and returns the associated quality score and nil error.
+// Otherwise it returns an zero score and error.
+func (e Encoding) Decode(char byte) (int8, error) {
+	switch e {
+	case Sanger:
+		return SangerPhred33(char)
+	case Illumina13:
+		return Illumina64V13(char)
+	case Illumina15:
+		return Illumina64V15(char)
+	case Illumina18:
+		return IlluminaPhred33(char)
+	case Solexa:
+		return Solexa64(char)
+	default:
+		return 0, fmt.Errorf("unknown quality encoding: %s", e)
+	}
+}
+
+// Encode takes a quality score and returns the single-byte ASCII character
+// used to represent it. Scores outside of the Encoding are clamped to its
+// lowest or highest character.
+func (e Encoding) Encode(score int8) byte {
+	low, _ := e.Decode(e.Min())
+	high, _ := e.Decode(e.Max())
+	switch {
+	case score < low:
+		score = low
+	case score > high:
+		score = high
+	}
+	return e.Min() + byte(score-low)
+}
+
+// solexa reports whether the Encoding uses Solexa scores rather than Phred scores
+func (e Encoding) solexa() bool {
+	return e == Solexa
+}
@@ -0,0 +1,56 @@
+package quality_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sembio/go/bio/data/quality"
+)
+
+func TestEncodingRange(t *testing.T) {
+	for _, e := range quality.Encodings() {
+		t.Run(e.String(), func(t *testing.T) {
+			for c := e.Min(); c <= e.Max(); c++ {
+				score, err := e.Decode(c)
+				if err != nil {
+					t.Errorf("Decode(%q) should not error, got %v", c, err)
+				}
+				if got := e.Encode(score); got != c {
+					t.Errorf("Encode(Decode(%q)) = %q", c, got)
+				}
+			}
+			if _, err := e.Decode(e.Min() - 1); err == nil {
+				t.Errorf("Decode(%q) below minimum should error", e.Min()-1)
+			}
+			if _, err := e.Decode(e.Max() + 1); err == nil {
+				t.Errorf("Decode(%q) above maximum should error", e.Max()+1)
+			}
+		})
+	}
+}
+
+func TestEncodingClamps(t *testing.T) {
+	tt := []struct {
+		e     quality.Encoding
+		score int8
+		want  byte
+	}{
+		{quality.Sanger, 41, quality.MaxSangerPhred33},
+		{quality.Sanger, -1, quality.MinPhred33},
+		{quality.Illumina15, 0, quality.MinIllumina64V15},
+		{quality.Solexa, -10, quality.MinSolexa64},
+	}
+	for _, tc := range tt {
+		t.Run(fmt.Sprintf("%s %d", tc.e, tc.score), func(t *testing.T) {
+			if got := tc.e.Encode(tc.score); got != tc.want {
+				t.Errorf("Want: %q, Got: %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func ExampleEncoding_Decode() {
+	fmt.Println(quality.Illumina13.Decode('h'))
+	// Output:
+	// 40 <nil>
+}
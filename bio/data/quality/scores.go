@@ -0,0 +1,153 @@
+package quality
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Scores are the quality scores for each position of a sequence
+type Scores struct {
+	scores []int8
+	enc    Encoding
+}
+
+// Decode reads a whole quality line written in the given Encoding.
+// Only the scores up to the first invalid character are kept (along with the error).
+func Decode(quality string, e Encoding) (*Scores, error) {
+	x := &Scores{
+		scores: make([]int8, 0, len(quality)),
+		enc:    e,
+	}
+	for i := 0; i < len(quality); i++ {
+		score, err := e.Decode(quality[i])
+		if err != nil {
+			return x, fmt.Errorf("position %d: %v", i, err)
+		}
+		x.scores = append(x.scores, score)
+	}
+	return x, nil
+}
+
+// Encoding is the Encoding the Scores were decoded from
+func (x *Scores) Encoding() Encoding {
+	return x.enc
+}
+
+// Length is the number of scores
+func (x *Scores) Length() uint {
+	return uint(len(x.scores))
+}
+
+// Scores are the scores as decoded (Solexa scores if decoded from Solexa)
+func (x *Scores) Scores() []int8 {
+	scores := make([]int8, len(x.scores))
+	copy(scores, x.scores)
+	return scores
+}
+
+// Phred are the scores on the Phred scale, converting from Solexa scores if needed
+func (x *Scores) Phred() []float64 {
+	phred := make([]float64, len(x.scores))
+	for i, s := range x.scores {
+		if x.enc.solexa() {
+			phred[i] = SolexaToPhred(float64(s))
+		} else {
+			phred[i] = float64(s)
+		}
+	}
+	return phred
+}
+
+// ErrorProbabilities are the probabilities that each position was called in error
+func (x *Scores) ErrorProbabilities() []float64 {
+	ps := make([]float64, len(x.scores))
+	for i, s := range x.scores {
+		p := math.Pow(10, -float64(s)/10)
+		if x.enc.solexa() {
+			p = p / (1 + p)
+		}
+		ps[i] = p
+	}
+	return ps
+}
+
+// Encode writes the scores as a quality line in the given Encoding.
+// Scores are converted between the Phred and Solexa scales (rounding to the
+// nearest score) and clamped to the range of the Encoding.
+func (x *Scores) Encode(e Encoding) string {
+	b := make([]byte, len(x.scores))
+	for i, s := range x.scores {
+		score := float64(s)
+		switch {
+		case x.enc.solexa() && !e.solexa():
+			score = SolexaToPhred(score)
+		case !x.enc.solexa() && e.solexa():
+			score = PhredToSolexa(score)
+		}
+		b[i] = e.Encode(clampInt8(math.Round(score)))
+	}
+	return string(b)
+}
+
+// Mean is the mean Phred score (0 if there are no scores)
+func (x *Scores) Mean() float64 {
+	if len(x.scores) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, s := range x.Phred() {
+		sum += s
+	}
+	return sum / float64(len(x.scores))
+}
+
+// Median is the median Phred score (0 if there are no scores)
+func (x *Scores) Median() float64 {
+	n := len(x.scores)
+	if n == 0 {
+		return 0
+	}
+	phred := x.Phred()
+	sort.Float64s(phred)
+	if n%2 == 1 {
+		return phred[n/2]
+	}
+	return (phred[n/2-1] + phred[n/2]) / 2
+}
+
+// ExpectedErrors is the expected number of positions called in error,
+// which is the sum of the error probabilities
+func (x *Scores) ExpectedErrors() float64 {
+	sum := 0.0
+	for _, p := range x.ErrorProbabilities() {
+		sum += p
+	}
+	return sum
+}
+
+// SolexaToPhred converts a Solexa score to the Phred score with the same error probability
+func SolexaToPhred(q float64) float64 {
+	return 10 * math.Log10(math.Pow(10, q/10)+1)
+}
+
+// PhredToSolexa converts a Phred score to the Solexa score with the same error probability.
+// A Phred score of 0 (certain error) has no Solexa equivalent so is given the lowest Solexa score.
+func PhredToSolexa(q float64) float64 {
+	if q <= 0 {
+		return -5
+	}
+	return math.Max(10*math.Log10(math.Pow(10, q/10)-1), -5)
+}
+
+// clampInt8 converts f to the closest int8
+func clampInt8(f float64) int8 {
+	switch {
+	case f < math.MinInt8:
+		return math.MinInt8
+	case f > math.MaxInt8:
+		return math.MaxInt8
+	default:
+		return int8(f)
+	}
+}
@@ -0,0 +1,123 @@
+package quality_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestScoresRoundTrip(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	for _, e := range quality.Encodings() {
+		e := e
+		properties.Property(fmt.Sprintf("Decode(s).Encode(%s) is s", e),
+			prop.ForAll(
+				func(n uint) bool {
+					valid := make([]rune, 0)
+					for c := e.Min(); c <= e.Max(); c++ {
+						valid = append(valid, rune(c))
+					}
+					s := test.RandomStringFromRunes(test.Seed, n, valid)
+					x, err := quality.Decode(s, e)
+					return err == nil && x.Encode(e) == s && x.Length() == n
+				},
+				gen.UIntRange(1, 1000),
+			),
+		)
+	}
+	properties.Property("Sanger and Illumina 1.3 differ by offset only",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(test.Seed, n, []rune("!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHI"))
+				x, _ := quality.Decode(s, quality.Sanger)
+				y, _ := quality.Decode(x.Encode(quality.Illumina13), quality.Illumina13)
+				return y.Encode(quality.Sanger) == s
+			},
+			gen.UIntRange(1, 1000),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestScoresErrors(t *testing.T) {
+	x, err := quality.Decode("II I", quality.Sanger)
+	if err == nil {
+		t.Error("space is not a Sanger symbol and should error")
+	}
+	if x.Length() != 2 {
+		t.Errorf("scores up to the error should be kept, Got: %d", x.Length())
+	}
+}
+
+func TestSolexaConversion(t *testing.T) {
+	tt := []struct {
+		solexa float64
+		phred  float64
+	}{
+		{-5, 1.193},
+		{0, 3.010},
+		{10, 10.414},
+		{40, 40.000},
+	}
+	for _, tc := range tt {
+		t.Run(fmt.Sprint(tc.solexa), func(t *testing.T) {
+			if got := quality.SolexaToPhred(tc.solexa); math.Abs(got-tc.phred) > 1e-3 {
+				t.Errorf("SolexaToPhred(%v) = %v; Want: %v", tc.solexa, got, tc.phred)
+			}
+			if got := quality.PhredToSolexa(tc.phred); math.Abs(got-tc.solexa) > 1e-2 {
+				t.Errorf("PhredToSolexa(%v) = %v; Want: %v", tc.phred, got, tc.solexa)
+			}
+		})
+	}
+	t.Run("Error probability is unchanged by conversion", func(t *testing.T) {
+		x, _ := quality.Decode(";@Jh", quality.Solexa)
+		y, _ := quality.Decode(x.Encode(quality.Sanger), quality.Sanger)
+		px, py := x.ErrorProbabilities(), y.ErrorProbabilities()
+		for i := range px {
+			if math.Abs(px[i]-py[i])/px[i] > 0.15 {
+				t.Errorf("position %d: Solexa %v, Phred %v", i, px[i], py[i])
+			}
+		}
+	})
+}
+
+func TestScoresSummaries(t *testing.T) {
+	x, _ := quality.Decode("+5?I", quality.Sanger) // 10, 20, 30, 40
+	if got := x.Mean(); got != 25 {
+		t.Errorf("Mean: Want: 25, Got: %v", got)
+	}
+	if got := x.Median(); got != 25 {
+		t.Errorf("Median: Want: 25, Got: %v", got)
+	}
+	if got := x.ExpectedErrors(); math.Abs(got-0.1111) > 1e-9 {
+		t.Errorf("ExpectedErrors: Want: 0.1111, Got: %v", got)
+	}
+	empty, _ := quality.Decode("", quality.Sanger)
+	if empty.Mean() != 0 || empty.Median() != 0 || empty.ExpectedErrors() != 0 {
+		t.Error("empty scores should summarize to zero")
+	}
+}
+
+func ExampleDecode() {
+	x, err := quality.Decode("II5+", quality.Sanger)
+
+	fmt.Println(x.Scores(), x.ErrorProbabilities(), err)
+	// Output:
+	// [40 40 20 10] [0.0001 0.0001 0.01 0.1] <nil>
+}
+
+func ExampleScores_Encode() {
+	x, _ := quality.Decode("hhTJ", quality.Illumina13)
+
+	fmt.Println(x.Encode(quality.Sanger), x.Encode(quality.Solexa))
+	// Output:
+	// II5+ hhTJ
+}
@@ -0,0 +1,8 @@
+/*
+Package thermo contains nearest-neighbor thermodynamic parameters for DNA duplexes.
+
+A duplex is built up from its stacks, the pairs of neighboring base pairs, each with an
+enthalpy and entropy of forming, plus terms for starting the duplex and for loops.
+See SantaLucia (1998) PNAS 95:1460 and SantaLucia & Hicks (2004) Annu Rev Biophys 33:415.
+*/
+package thermo
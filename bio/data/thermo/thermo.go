@@ -0,0 +1,122 @@
+package thermo
+
+import (
+	"math"
+)
+
+// Kelvin is 0 °C in kelvin
+const Kelvin = 273.15
+
+// R is the gas constant in cal/K/mol
+const R = 1.9872
+
+// Params are the enthalpy (kcal/mol) and entropy (cal/K/mol) of forming part of a duplex
+type Params struct {
+	H float64
+	S float64
+}
+
+// G is the free energy (kcal/mol) at a temperature in °C
+func (p Params) G(celsius float64) float64 {
+	return p.H - (celsius+Kelvin)*p.S/1000
+}
+
+// Add is the sum of the parameters
+func (p Params) Add(q Params) Params {
+	return Params{p.H + q.H, p.S + q.S}
+}
+
+// Interface is a full set of nearest-neighbor parameters
+type Interface interface {
+	Stacker
+	Initiator
+	HairpinLooper
+}
+
+// Stacker gives the parameters of a stack, written as the two bases of the top strand
+// 5' to 3' (e.g. "CA" for CA/GT) paired with their Watson-Crick complements
+type Stacker interface {
+	Stack(string) (Params, bool)
+}
+
+// Initiator gives the parameters of starting a duplex, for each terminal base pair
+// given by its top strand base, and of a duplex of two identical strands
+type Initiator interface {
+	Init(byte) (Params, bool)
+	Symmetry() Params
+}
+
+// HairpinLooper gives the parameters of closing a hairpin loop of n unpaired bases
+type HairpinLooper interface {
+	HairpinLoop(n int) (Params, bool)
+}
+
+// SantaLucia1998 are the unified nearest-neighbor parameters of SantaLucia (1998) in
+// 1 M NaCl, with the hairpin loops of SantaLucia & Hicks (2004)
+type SantaLucia1998 struct{}
+
+var _ Interface = SantaLucia1998{}
+
+// Stack gives the parameters of a Watson-Crick stack
+func (SantaLucia1998) Stack(nn string) (Params, bool) {
+	p, ok := map[string]Params{
+		"AA": {-7.9, -22.2}, "TT": {-7.9, -22.2},
+		"AT": {-7.2, -20.4},
+		"TA": {-7.2, -21.3},
+		"CA": {-8.5, -22.7}, "TG": {-8.5, -22.7},
+		"GT": {-8.4, -22.4}, "AC": {-8.4, -22.4},
+		"CT": {-7.8, -21.0}, "AG": {-7.8, -21.0},
+		"GA": {-8.2, -22.2}, "TC": {-8.2, -22.2},
+		"CG": {-10.6, -27.2},
+		"GC": {-9.8, -24.4},
+		"GG": {-8.0, -19.9}, "CC": {-8.0, -19.9},
+	}[nn]
+	return p, ok
+}
+
+// Init gives the parameters of starting a duplex at a terminal G·C or A·T pair
+func (SantaLucia1998) Init(b byte) (Params, bool) {
+	switch b {
+	case 'G', 'C':
+		return Params{0.1, -2.8}, true
+	case 'A', 'T':
+		return Params{2.3, 4.1}, true
+	}
+	return Params{}, false
+}
+
+// Symmetry gives the parameters of a duplex of two identical, self-complementary strands
+func (SantaLucia1998) Symmetry() Params {
+	return Params{0, -1.4}
+}
+
+// hairpinLoops are the free energies (kcal/mol at 37 °C) of hairpin loops by size
+var hairpinLoops = []struct {
+	n int
+	g float64
+}{
+	{3, 3.5}, {4, 3.5}, {5, 3.3}, {6, 4.0}, {7, 4.2}, {8, 4.3}, {9, 4.5}, {10, 4.6},
+	{12, 5.0}, {14, 5.1}, {16, 5.3}, {18, 5.5}, {20, 5.7}, {25, 6.1}, {30, 6.3},
+}
+
+// HairpinLoop gives the parameters of closing a hairpin loop of at least 3 bases. Loops
+// are entirely entropic. Sizes between those measured are interpolated, and sizes beyond
+// them are extrapolated by the Jacobson-Stockmayer equation.
+func (SantaLucia1998) HairpinLoop(n int) (Params, bool) {
+	if n < 3 {
+		return Params{}, false
+	}
+	var g float64
+	last := hairpinLoops[len(hairpinLoops)-1]
+	if n >= last.n {
+		g = last.g + 2.44*R*(37+Kelvin)/1000*math.Log(float64(n)/float64(last.n))
+	} else {
+		i := 0
+		for hairpinLoops[i+1].n <= n {
+			i++
+		}
+		a, b := hairpinLoops[i], hairpinLoops[i+1]
+		g = a.g + (b.g-a.g)*float64(n-a.n)/float64(b.n-a.n)
+	}
+	return Params{0, -g * 1000 / (37 + Kelvin)}, true
+}
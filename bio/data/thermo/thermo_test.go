@@ -0,0 +1,70 @@
+package thermo_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/sembio/go/bio/data/thermo"
+)
+
+func TestStack(t *testing.T) {
+	table := thermo.SantaLucia1998{}
+	comp := map[byte]byte{'A': 'T', 'C': 'G', 'G': 'C', 'T': 'A'}
+	for _, a := range "ACGT" {
+		for _, b := range "ACGT" {
+			nn := string([]rune{a, b})
+			rc := string([]byte{comp[byte(b)], comp[byte(a)]})
+			p, ok := table.Stack(nn)
+			q, _ := table.Stack(rc)
+			if !ok || p != q || p.G(37) >= 0 {
+				t.Errorf("%s Want: a stable stack the same as %s, Got: %v %v", nn, rc, p, q)
+			}
+		}
+	}
+	if _, ok := table.Stack("AN"); ok {
+		t.Error("Want: no stack for ambiguous bases")
+	}
+	if g := (thermo.Params{H: -10.6, S: -27.2}).G(37); math.Abs(g-(-2.164)) > 0.001 {
+		t.Errorf("Want: CG/GC -2.16 kcal/mol at 37 °C, Got: %f", g)
+	}
+}
+
+func TestInit(t *testing.T) {
+	table := thermo.SantaLucia1998{}
+	gc, _ := table.Init('G')
+	at, ok := table.Init('T')
+	if !ok || gc.G(37) >= at.G(37) {
+		t.Errorf("Want: A·T ends less stable than G·C, Got: %v %v", at, gc)
+	}
+	if _, ok := table.Init('N'); ok {
+		t.Error("Want: no initiation for ambiguous bases")
+	}
+}
+
+func TestHairpinLoop(t *testing.T) {
+	table := thermo.SantaLucia1998{}
+	if _, ok := table.HairpinLoop(2); ok {
+		t.Error("Want: no loops under 3 bases")
+	}
+	for n, want := range map[int]float64{3: 3.5, 6: 4.0, 30: 6.3} {
+		if p, _ := table.HairpinLoop(n); math.Abs(p.G(37)-want) > 1e-9 {
+			t.Errorf("%d Want: %f, Got: %f", n, want, p.G(37))
+		}
+	}
+	prev := 0.0
+	for n := 6; n < 100; n++ {
+		p, _ := table.HairpinLoop(n)
+		if p.G(37) < prev {
+			t.Errorf("Want: longer loops to cost more, Got: %f at %d", p.G(37), n)
+		}
+		prev = p.G(37)
+	}
+}
+
+func ExampleParams_G() {
+	table := thermo.SantaLucia1998{}
+	cg, _ := table.Stack("CG")
+	fmt.Printf("%.2f kcal/mol\n", cg.G(37))
+	// Output: -2.16 kcal/mol
+}
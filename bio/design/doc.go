@@ -0,0 +1,4 @@
+/*
+Package design is a collection of tools for designing biological sequences
+*/
+package design
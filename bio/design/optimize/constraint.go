@@ -0,0 +1,117 @@
+package optimize
+
+import (
+	"strings"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
+)
+
+// Constraint lists the half-open ranges of a DNA sequence which violate it
+type Constraint func(dna string) [][2]uint
+
+// AvoidSites is violated by any occurrence of the given sites on either strand.
+// Sites are written in IUPAC DNA so ambiguous sites such as "GGTNACC" are allowed.
+func AvoidSites(sites ...string) Constraint {
+	a := hashmap.NewDnaIupac()
+	patterns := make([]string, 0, 2*len(sites))
+	for _, s := range sites {
+		s = strings.ToUpper(s)
+		rc := make([]byte, len(s))
+		for i := range s {
+			rc[len(s)-1-i] = a.Complement(string(s[i]))[0]
+		}
+		patterns = append(patterns, s, string(rc))
+	}
+	return func(dna string) [][2]uint {
+		vs := make([][2]uint, 0)
+		for i := range dna {
+			for _, p := range patterns {
+				if matchesAt(a, p, dna, i) {
+					vs = append(vs, [2]uint{uint(i), uint(i + len(p))})
+					break
+				}
+			}
+		}
+		return vs
+	}
+}
+
+// matchesAt reports whether the IUPAC pattern p matches dna starting at i
+func matchesAt(a *hashmap.DnaIupac, p, dna string, i int) bool {
+	if i+len(p) > len(dna) || len(p) == 0 {
+		return false
+	}
+	for j := range p {
+		if !strings.Contains(a.Expand(string(p[j])), string(dna[i+j])) {
+			return false
+		}
+	}
+	return true
+}
+
+// GCWindow is violated by any window of the given size whose fraction of G and C
+// falls outside of [min, max].
+// A sequence shorter than the window is treated as a single window.
+func GCWindow(size uint, min, max float64) Constraint {
+	return func(dna string) [][2]uint {
+		n := len(dna)
+		w := int(size)
+		if w > n {
+			w = n
+		}
+		vs := make([][2]uint, 0)
+		if w == 0 {
+			return vs
+		}
+		gc := 0
+		for i := 0; i < n; i++ {
+			if dna[i] == 'G' || dna[i] == 'C' {
+				gc++
+			}
+			if i >= w && (dna[i-w] == 'G' || dna[i-w] == 'C') {
+				gc--
+			}
+			if i >= w-1 {
+				if f := float64(gc) / float64(w); f < min || max < f {
+					vs = append(vs, [2]uint{uint(i + 1 - w), uint(i + 1)})
+				}
+			}
+		}
+		return vs
+	}
+}
+
+// MaxHomopolymer is violated by any run of a single letter longer than n
+func MaxHomopolymer(n uint) Constraint {
+	return func(dna string) [][2]uint {
+		vs := make([][2]uint, 0)
+		start := 0
+		for i := 1; i <= len(dna); i++ {
+			if i == len(dna) || dna[i] != dna[start] {
+				if uint(i-start) > n {
+					vs = append(vs, [2]uint{uint(start), uint(i)})
+				}
+				start = i
+			}
+		}
+		return vs
+	}
+}
+
+// violations lists every violated range over all constraints
+func violations(dna string, cs []Constraint) [][2]uint {
+	vs := make([][2]uint, 0)
+	for _, c := range cs {
+		vs = append(vs, c(dna)...)
+	}
+	return vs
+}
+
+// span is the total length of violated ranges
+func span(vs [][2]uint) uint {
+	total := uint(0)
+	for _, v := range vs {
+		total += v[1] - v[0]
+	}
+	return total
+}
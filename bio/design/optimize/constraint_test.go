@@ -0,0 +1,67 @@
+package optimize_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sembio/go/bio/design/optimize"
+)
+
+func TestAvoidSites(t *testing.T) {
+	tt := []struct {
+		sites []string
+		dna   string
+		want  [][2]uint
+	}{
+		{[]string{"GAATTC"}, "AAGAATTCAA", [][2]uint{{2, 8}}},
+		{[]string{"GGTCTC"}, "AAGAGACCAA", [][2]uint{{2, 8}}},
+		{[]string{"GANTC"}, "GACTCGATTC", [][2]uint{{0, 5}, {5, 10}}},
+		{[]string{"GAATTC"}, "AAAAAA", [][2]uint{}},
+	}
+	for _, tc := range tt {
+		t.Run(fmt.Sprint(tc.sites, tc.dna), func(t *testing.T) {
+			if got := optimize.AvoidSites(tc.sites...)(tc.dna); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Want: %v, Got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGCWindow(t *testing.T) {
+	tt := []struct {
+		size uint
+		dna  string
+		want [][2]uint
+	}{
+		{4, "ATATGCGC", [][2]uint{{0, 4}, {4, 8}}},
+		{4, "ATGCATGC", [][2]uint{}},
+		{10, "GGGG", [][2]uint{{0, 4}}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.dna, func(t *testing.T) {
+			if got := optimize.GCWindow(tc.size, 0.25, 0.75)(tc.dna); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Want: %v, Got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMaxHomopolymer(t *testing.T) {
+	tt := []struct {
+		n    uint
+		dna  string
+		want [][2]uint
+	}{
+		{3, "AAAATTTGGGGG", [][2]uint{{0, 4}, {7, 12}}},
+		{3, "AAATTTGGG", [][2]uint{}},
+		{0, "", [][2]uint{}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.dna, func(t *testing.T) {
+			if got := optimize.MaxHomopolymer(tc.n)(tc.dna); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Want: %v, Got: %v", tc.want, got)
+			}
+		})
+	}
+}
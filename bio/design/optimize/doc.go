@@ -0,0 +1,8 @@
+/*
+Package optimize designs coding DNA for a protein that suits a target organism.
+
+Codons are first chosen by a Strategy using the codon usage of the target organism,
+then synonymous codons are swapped until every Constraint is met (where possible).
+Whatever codons are chosen, the designed DNA translates back to the same protein.
+*/
+package optimize
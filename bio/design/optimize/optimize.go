@@ -0,0 +1,281 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	gencode "github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/codon"
+	"github.com/sembio/go/bio/utils"
+)
+
+// Strategy is how codons are first chosen before constraints are met
+type Strategy int
+
+const (
+	// MostFrequent chooses the most used synonymous codon of the target
+	MostFrequent Strategy = iota
+
+	// MatchUsage samples synonymous codons in proportion to their use by the target
+	MatchUsage
+
+	// Harmonize chooses the synonymous codon whose use by the target is closest to
+	// the use of the original codon by the source organism (see SourceIs).
+	// Harmonizing requires the original coding DNA and the usage of the source
+	// so only applies to Recode with a source set.
+	Harmonize
+)
+
+// MaxRounds is the most passes made over a design swapping codons to meet constraints
+const MaxRounds = 100
+
+// Optimizer designs coding DNA using the codon usage of a target organism
+type Optimizer struct {
+	table       gencode.Interface
+	target      *codon.Usage
+	source      *codon.Usage
+	strategy    Strategy
+	constraints []Constraint
+	seed        int64
+}
+
+// Option is a setting of an Optimizer
+type Option func(*Optimizer)
+
+// StrategyIs sets how codons are first chosen (MostFrequent by default)
+func StrategyIs(s Strategy) Option {
+	return func(o *Optimizer) {
+		o.strategy = s
+	}
+}
+
+// SourceIs sets the codon usage of the organism the original coding DNA came from
+func SourceIs(u *codon.Usage) Option {
+	return func(o *Optimizer) {
+		o.source = u
+	}
+}
+
+// ConstraintsAre sets the constraints a design should meet
+func ConstraintsAre(cs ...Constraint) Option {
+	return func(o *Optimizer) {
+		o.constraints = append(o.constraints, cs...)
+	}
+}
+
+// SeedIs sets the seed used wherever a design makes random choices (1 by default)
+// so the same settings and seed always produce the same design
+func SeedIs(seed int64) Option {
+	return func(o *Optimizer) {
+		o.seed = seed
+	}
+}
+
+// New generates an Optimizer for the target codon usage under a codon table
+func New(table gencode.Interface, target *codon.Usage, opts ...Option) *Optimizer {
+	o := &Optimizer{
+		table:       table,
+		target:      target,
+		strategy:    MostFrequent,
+		constraints: make([]Constraint, 0),
+		seed:        1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// BackTranslate designs coding DNA for protein p
+func (o *Optimizer) BackTranslate(p sequence.Interface) (*immutable.Dna, error) {
+	if o.strategy == Harmonize {
+		return nil, fmt.Errorf("harmonizing requires the original coding DNA")
+	}
+	aas, err := p.Range(0, p.Length())
+	if err != nil {
+		return nil, err
+	}
+	return o.design([]byte(strings.ToUpper(aas)), nil)
+}
+
+// Recode designs new coding DNA for the protein encoded by coding DNA d (read in frame 1).
+// Stop codons are kept as stop codons.
+func (o *Optimizer) Recode(d sequence.Interface) (*immutable.Dna, error) {
+	if o.strategy == Harmonize && o.source == nil {
+		return nil, fmt.Errorf("harmonizing requires the codon usage of the source organism")
+	}
+	seq, err := d.Range(0, d.Length())
+	if err != nil {
+		return nil, err
+	}
+	seq = strings.ToUpper(seq)
+	if len(seq)%3 != 0 {
+		return nil, fmt.Errorf("coding DNA length [%d] is not a multiple of three", len(seq))
+	}
+	cdns := make([]string, len(seq)/3)
+	aas := make([]byte, len(cdns))
+	for i := range cdns {
+		cdns[i] = seq[i*3 : i*3+3]
+		if aas[i], err = o.translate(cdns[i]); err != nil {
+			return nil, err
+		}
+	}
+	return o.design(aas, cdns)
+}
+
+// translate is the amino acid of codon c with stop codons as '*'
+func (o *Optimizer) translate(c string) (byte, error) {
+	if utils.InStrings(c, o.table.StopCodons()) {
+		return '*', nil
+	}
+	if aa, ok := o.table.Translate(c); ok {
+		return aa, nil
+	}
+	return 0, fmt.Errorf("failed to translate codon: %q when using %s", c, o.table)
+}
+
+// synonyms lists the codons for amino acid aa (with '*' as stop)
+// from most to least used by the target
+func (o *Optimizer) synonyms(aa byte) []string {
+	var cdns []string
+	if aa == '*' {
+		cdns = append(cdns, o.table.StopCodons()...)
+	} else {
+		cdns = gencode.Codons(o.table, aa)
+	}
+	sorted := make([]string, 0, len(cdns))
+	for _, c := range cdns {
+		i := len(sorted)
+		for i > 0 && o.target.Count(c) > o.target.Count(sorted[i-1]) {
+			i--
+		}
+		sorted = append(sorted, "")
+		copy(sorted[i+1:], sorted[i:])
+		sorted[i] = c
+	}
+	return sorted
+}
+
+// design chooses codons for each amino acid then meets constraints
+func (o *Optimizer) design(aas []byte, original []string) (*immutable.Dna, error) {
+	rng := rand.New(rand.NewSource(o.seed))
+	syns := make(map[byte][]string)
+	cdns := make([]string, len(aas))
+	for i, aa := range aas {
+		if _, ok := syns[aa]; !ok {
+			syns[aa] = o.synonyms(aa)
+			if len(syns[aa]) == 0 {
+				return nil, fmt.Errorf("failed to back-translate amino acid: %q when using %s", aa, o.table)
+			}
+		}
+		switch o.strategy {
+		case MatchUsage:
+			cdns[i] = o.sample(syns[aa], rng)
+		case Harmonize:
+			var err error
+			if cdns[i], err = o.harmonize(original[i], aa, syns[aa]); err != nil {
+				return nil, err
+			}
+		default:
+			cdns[i] = o.target.MostUsed(syns[aa])
+		}
+	}
+
+	remaining := o.constrain(cdns, aas, syns)
+	dna, err := immutable.NewDna(strings.Join(cdns, ""))
+	if err != nil {
+		return dna, err
+	}
+	for i, c := range cdns {
+		if aa, err := o.translate(c); err != nil || aa != aas[i] {
+			return dna, fmt.Errorf("design does not translate back at codon %d", i)
+		}
+	}
+	if remaining != 0 {
+		return dna, fmt.Errorf("%d constraint violations remain", remaining)
+	}
+	return dna, nil
+}
+
+// sample chooses one of the synonymous codons in proportion to use by the target,
+// choosing uniformly if none are used
+func (o *Optimizer) sample(syns []string, rng *rand.Rand) string {
+	total := uint(0)
+	for _, c := range syns {
+		total += o.target.Count(c)
+	}
+	if total == 0 {
+		return syns[rng.Intn(len(syns))]
+	}
+	r := uint(rng.Int63n(int64(total)))
+	for _, c := range syns {
+		if r < o.target.Count(c) {
+			return c
+		}
+		r -= o.target.Count(c)
+	}
+	return syns[len(syns)-1]
+}
+
+// harmonize chooses the synonymous codon for amino acid aa whose relative use by the
+// target is closest to the relative use of the original codon by the source
+func (o *Optimizer) harmonize(original string, aa byte, syns []string) (string, error) {
+	rel := func(u *codon.Usage, c string) float64 {
+		max := uint(0)
+		for _, s := range syns {
+			if u.Count(s) > max {
+				max = u.Count(s)
+			}
+		}
+		if max == 0 {
+			return math.NaN()
+		}
+		return float64(u.Count(c)) / float64(max)
+	}
+	want := rel(o.source, original)
+	if math.IsNaN(want) {
+		return "", fmt.Errorf("source codon usage has no codons for amino acid: %q", aa)
+	}
+	best, diff := syns[0], math.Inf(1)
+	for _, c := range syns {
+		if d := math.Abs(rel(o.target, c) - want); d < diff {
+			best, diff = c, d
+		}
+	}
+	return best, nil
+}
+
+// constrain swaps synonymous codons, in order of use by the target, wherever a
+// swap reduces the span of violations and returns the number that remain
+func (o *Optimizer) constrain(cdns []string, aas []byte, syns map[byte][]string) int {
+	vs := violations(strings.Join(cdns, ""), o.constraints)
+	for round := 0; round < MaxRounds && len(vs) != 0; round++ {
+		improved := false
+	violation:
+		for _, v := range vs {
+			for k := v[0] / 3; k < (v[1]+2)/3 && k < uint(len(cdns)); k++ {
+				was := cdns[k]
+				for _, alt := range syns[aas[k]] {
+					if alt == was {
+						continue
+					}
+					cdns[k] = alt
+					if next := violations(strings.Join(cdns, ""), o.constraints); span(next) < span(vs) {
+						vs = next
+						improved = true
+						continue violation
+					}
+				}
+				cdns[k] = was
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return len(vs)
+}
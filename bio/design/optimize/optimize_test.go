@@ -0,0 +1,227 @@
+package optimize_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	gencode "github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/design/optimize"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/codon"
+	"github.com/sembio/go/bio/test"
+)
+
+// usage is a small, strongly biased E. coli-like codon usage
+func usage() *codon.Usage {
+	u, _ := codon.ReadKazusa(strings.NewReader(`
+UUU 22.1( 221)  UCU  8.5(  85)  UAU 16.2( 162)  UGU  5.2(  52)
+UUC 16.0( 160)  UCC  8.6(  86)  UAC 12.2( 122)  UGC  6.1(  61)
+UUA 13.9( 139)  UCA  7.2(  72)  UAA  2.0(  20)  UGA  1.0(  10)
+UUG 13.7( 137)  UCG  8.9(  89)  UAG  0.2(   2)  UGG 15.2( 152)
+CUU 11.0( 110)  CCU  7.0(  70)  CAU 12.9( 129)  CGU 20.9( 209)
+CUC 11.0( 110)  CCC  5.5(  55)  CAC  9.7(  97)  CGC 22.0( 220)
+CUA  3.9(  39)  CCA  8.5(  85)  CAA 15.3( 153)  CGA  3.6(  36)
+CUG 52.6( 526)  CCG 23.2( 232)  CAG 28.8( 288)  CGG  5.4(  54)
+AUU 30.3( 303)  ACU  8.9(  89)  AAU 17.7( 177)  AGU  8.7(  87)
+AUC 25.0( 250)  ACC 23.4( 234)  AAC 21.7( 217)  AGC 16.0( 160)
+AUA  4.4(  44)  ACA  7.1(  71)  AAA 33.6( 336)  AGA  2.1(  21)
+AUG 27.8( 278)  ACG 14.4( 144)  AAG 10.3( 103)  AGG  1.2(  12)
+GUU 18.3( 183)  GCU 15.3( 153)  GAU 32.1( 321)  GGU 24.7( 247)
+GUC 15.3( 153)  GCC 25.5( 255)  GAC 19.1( 191)  GGC 29.6( 296)
+GUA 10.9( 109)  GCA 20.3( 203)  GAA 39.4( 394)  GGA  8.0(  80)
+GUG 26.4( 264)  GCG 33.6( 336)  GAG 17.8( 178)  GGG 11.1( 111)
+`))
+	return u
+}
+
+// translate is the protein (with '*' as stop) coded by dna under table t
+func translate(dna string, t gencode.Interface) string {
+	aas := make([]byte, len(dna)/3)
+	for i := range aas {
+		c := dna[i*3 : i*3+3]
+		aas[i] = '*'
+		if aa, ok := t.Translate(c); ok {
+			aas[i] = aa
+		}
+	}
+	return string(aas)
+}
+
+func TestBackTranslate(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	for _, s := range []optimize.Strategy{optimize.MostFrequent, optimize.MatchUsage} {
+		for _, table := range []gencode.Interface{gencode.Standard{}, gencode.VertebrateMt{}} {
+			strategy, table := s, table
+			properties.Property(fmt.Sprintf("Strategy %d translates back under %s", strategy, table),
+				prop.ForAll(
+					func(n uint) bool {
+						s := test.RandomStringFromRunes(
+							test.Seed,
+							n,
+							[]rune(hashmap.NewProtein().String()),
+						)
+						p, _ := immutable.NewProtein(s)
+						o := optimize.New(table, usage(),
+							optimize.StrategyIs(strategy),
+							optimize.ConstraintsAre(optimize.AvoidSites("GGATCC", "GAATTC")),
+						)
+						dna, err := o.BackTranslate(p)
+						return err == nil && translate(dna.String(), table) == s
+					},
+					gen.UIntRange(1, sequence.TestableLength/10),
+				),
+			)
+		}
+	}
+	properties.TestingRun(t)
+
+	t.Run("MostFrequent chooses the most used codons", func(t *testing.T) {
+		p, _ := immutable.NewProtein("MKLE")
+		dna, _ := optimize.New(gencode.Standard{}, usage()).BackTranslate(p)
+		if dna.String() != "ATGAAACTGGAA" {
+			t.Errorf("Want: %q, Got: %q", "ATGAAACTGGAA", dna)
+		}
+	})
+	t.Run("Harmonize requires coding DNA", func(t *testing.T) {
+		p, _ := immutable.NewProtein("MKLE")
+		o := optimize.New(gencode.Standard{}, usage(), optimize.StrategyIs(optimize.Harmonize))
+		if _, err := o.BackTranslate(p); err == nil {
+			t.Error("harmonizing a protein should error")
+		}
+	})
+	t.Run("Untranslatable amino acid errors", func(t *testing.T) {
+		if _, err := optimize.New(gencode.Standard{}, usage()).BackTranslate(immutable.New("MXK")); err == nil {
+			t.Error("amino acid X should error")
+		}
+	})
+}
+
+func TestSeedIsReproducible(t *testing.T) {
+	p, _ := immutable.NewProtein(test.RandomStringFromRunes(test.Seed, 200, []rune(hashmap.NewProtein().String())))
+	design := func(seed int64) string {
+		o := optimize.New(gencode.Standard{}, usage(),
+			optimize.StrategyIs(optimize.MatchUsage),
+			optimize.SeedIs(seed),
+		)
+		dna, _ := o.BackTranslate(p)
+		return dna.String()
+	}
+	if design(7) != design(7) {
+		t.Error("the same seed should produce the same design")
+	}
+	if design(7) == design(8) {
+		t.Error("different seeds should produce different designs")
+	}
+}
+
+func TestConstraintsAreMet(t *testing.T) {
+	tt := []struct {
+		name       string
+		protein    string
+		constraint optimize.Constraint
+	}{
+		{"Avoids site made by Gly-Ser", "MGSGSK", optimize.AvoidSites("GGCAGC")},
+		{"Avoids ambiguous site on reverse strand", "MDPK", optimize.AvoidSites("GGATCN")},
+		{"Breaks homopolymer of Lys", "MKKKKFF", optimize.MaxHomopolymer(4)},
+		{"Keeps GC within window", "MKKNNIIKKNN", optimize.GCWindow(12, 0.25, 0.75)},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := immutable.NewProtein(tc.protein)
+			free, _ := optimize.New(gencode.Standard{}, usage()).BackTranslate(p)
+			if len(tc.constraint(free.String())) == 0 {
+				t.Fatalf("unconstrained design %q should violate the constraint", free)
+			}
+			o := optimize.New(gencode.Standard{}, usage(), optimize.ConstraintsAre(tc.constraint))
+			dna, err := o.BackTranslate(p)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if vs := tc.constraint(dna.String()); len(vs) != 0 {
+				t.Errorf("design %q violates constraint at %v", dna, vs)
+			}
+			if got := translate(dna.String(), gencode.Standard{}); got != tc.protein {
+				t.Errorf("design translates to %q; Want: %q", got, tc.protein)
+			}
+		})
+	}
+	t.Run("Impossible constraint errors", func(t *testing.T) {
+		p, _ := immutable.NewProtein("MW")
+		o := optimize.New(gencode.Standard{}, usage(), optimize.ConstraintsAre(optimize.AvoidSites("TGG")))
+		if _, err := o.BackTranslate(p); err == nil {
+			t.Error("Trp has only TGG so should error")
+		}
+	})
+}
+
+func TestRecode(t *testing.T) {
+	source := codon.NewUsage()
+	native, _ := immutable.NewDna("ATGCTACTACTACTGAAGAAGAAATAG")
+	source.Add(native, 1)
+	t.Run("Recode keeps the protein and stop", func(t *testing.T) {
+		dna, err := optimize.New(gencode.Standard{}, usage()).Recode(native)
+		if err != nil || translate(dna.String(), gencode.Standard{}) != translate(native.String(), gencode.Standard{}) {
+			t.Errorf("Recode changed protein: %q %v", dna, err)
+		}
+		if !strings.HasSuffix(dna.String(), "TAA") {
+			t.Errorf("stop codon should be the most used stop, Got: %q", dna)
+		}
+	})
+	t.Run("Harmonize follows the source usage", func(t *testing.T) {
+		o := optimize.New(gencode.Standard{}, usage(),
+			optimize.StrategyIs(optimize.Harmonize),
+			optimize.SourceIs(source),
+		)
+		dna, err := o.Recode(native)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// CTA is the most used Leu of the source so becomes the most used Leu of the target
+		// while CTG is rarely used by the source so becomes a rarely used Leu of the target
+		if got := dna.String()[3:6]; got != "CTG" {
+			t.Errorf("Want: %q, Got: %q", "CTG", got)
+		}
+		if got := dna.String()[12:15]; got == "CTG" {
+			t.Errorf("rare source codon should not become the preferred target codon")
+		}
+	})
+	t.Run("Harmonize without source usage errors", func(t *testing.T) {
+		o := optimize.New(gencode.Standard{}, usage(), optimize.StrategyIs(optimize.Harmonize))
+		if _, err := o.Recode(native); err == nil {
+			t.Error("harmonizing without a source should error")
+		}
+		o = optimize.New(gencode.Standard{}, usage(),
+			optimize.StrategyIs(optimize.Harmonize),
+			optimize.SourceIs(codon.NewUsage()),
+		)
+		if _, err := o.Recode(native); err == nil {
+			t.Error("harmonizing codons the source never used should error")
+		}
+	})
+	t.Run("Frame errors", func(t *testing.T) {
+		d, _ := immutable.NewDna("ATGA")
+		if _, err := optimize.New(gencode.Standard{}, usage()).Recode(d); err == nil {
+			t.Error("length not divisible by three should error")
+		}
+	})
+}
+
+func ExampleOptimizer_BackTranslate() {
+	p, _ := immutable.NewProtein("MGSK")
+	o := optimize.New(gencode.Standard{}, usage(),
+		optimize.ConstraintsAre(optimize.AvoidSites("GGCAGC")),
+	)
+	dna, err := o.BackTranslate(p)
+
+	fmt.Println(dna, err)
+	// Output:
+	// ATGGGTAGCAAA <nil>
+}
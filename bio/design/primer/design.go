@@ -0,0 +1,347 @@
+package primer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Primer is a candidate primer binding a template
+type Primer struct {
+	Start     int  // start of the bound template, on its top strand
+	End       int  // end of the bound template, on its top strand
+	Strand    byte // '+' for forward primers and '-' for reverse primers
+	Sequence  *immutable.Dna
+	Tm        float64 // °C
+	GC        float64 // fraction of G and C
+	Hairpin   float64 // kcal/mol
+	SelfDimer float64 // kcal/mol
+	Penalty   float64
+}
+
+// Pair is a candidate pair of primers amplifying a product across a target
+type Pair struct {
+	Forward    Primer
+	Reverse    Primer
+	Product    int     // length of the product
+	CrossDimer float64 // kcal/mol
+	Penalty    float64
+}
+
+// Designer designs pairs of primers to amplify a target
+type Designer struct {
+	minLength, optLength, maxLength int
+	minTm, optTm, maxTm             float64
+	maxTmDifference                 float64
+	minGC, maxGC                    float64
+	gcClamp                         int
+	maxPolyX                        int
+	hairpin, selfDimer, crossDimer  float64
+	minProduct, maxProduct          int
+	maxPairs                        int
+	conditions                      []Condition
+}
+
+// Option is a setting of a Designer
+type Option func(*Designer)
+
+// LengthIs sets the shortest, optimal, and longest primers (18, 20, and 25 by default)
+func LengthIs(min, opt, max int) Option {
+	return func(d *Designer) {
+		d.minLength, d.optLength, d.maxLength = min, opt, max
+	}
+}
+
+// TmIs sets the lowest, optimal, and highest melting temperatures of primers in °C
+// (57, 60, and 63 by default)
+func TmIs(min, opt, max float64) Option {
+	return func(d *Designer) {
+		d.minTm, d.optTm, d.maxTm = min, opt, max
+	}
+}
+
+// MaxTmDifferenceIs sets the largest difference in °C between the melting temperatures
+// of a pair (5 by default)
+func MaxTmDifferenceIs(celsius float64) Option {
+	return func(d *Designer) {
+		d.maxTmDifference = celsius
+	}
+}
+
+// GCIs sets the lowest and highest fraction of G and C in primers (0.3 and 0.7 by default)
+func GCIs(min, max float64) Option {
+	return func(d *Designer) {
+		d.minGC, d.maxGC = min, max
+	}
+}
+
+// GCClampIs sets how many bases at the 3' end of primers must be G or C (1 by default)
+func GCClampIs(n int) Option {
+	return func(d *Designer) {
+		d.gcClamp = n
+	}
+}
+
+// MaxPolyXIs sets the longest run of one base in primers (4 by default)
+func MaxPolyXIs(n int) Option {
+	return func(d *Designer) {
+		d.maxPolyX = n
+	}
+}
+
+// HairpinLimitIs sets the most negative free energy in kcal/mol of the hairpins of
+// primers (-3 by default)
+func HairpinLimitIs(kcal float64) Option {
+	return func(d *Designer) {
+		d.hairpin = kcal
+	}
+}
+
+// SelfDimerLimitIs sets the most negative free energy in kcal/mol of primers paired
+// with themselves (-9 by default)
+func SelfDimerLimitIs(kcal float64) Option {
+	return func(d *Designer) {
+		d.selfDimer = kcal
+	}
+}
+
+// CrossDimerLimitIs sets the most negative free energy in kcal/mol of the primers of a
+// pair paired with each other (-9 by default)
+func CrossDimerLimitIs(kcal float64) Option {
+	return func(d *Designer) {
+		d.crossDimer = kcal
+	}
+}
+
+// ProductIs sets the shortest and longest products (100 and 300 by default)
+func ProductIs(min, max int) Option {
+	return func(d *Designer) {
+		d.minProduct, d.maxProduct = min, max
+	}
+}
+
+// MaxPairsIs sets how many pairs are returned (5 by default)
+func MaxPairsIs(n int) Option {
+	return func(d *Designer) {
+		d.maxPairs = n
+	}
+}
+
+// ConditionsAre sets the conditions of the reaction used for melting temperatures and
+// free energies
+func ConditionsAre(cs ...Condition) Option {
+	return func(d *Designer) {
+		d.conditions = append(d.conditions, cs...)
+	}
+}
+
+// New generates a Designer
+func New(opts ...Option) *Designer {
+	d := &Designer{
+		minLength:       18,
+		optLength:       20,
+		maxLength:       25,
+		minTm:           57,
+		optTm:           60,
+		maxTm:           63,
+		maxTmDifference: 5,
+		minGC:           0.3,
+		maxGC:           0.7,
+		gcClamp:         1,
+		maxPolyX:        4,
+		hairpin:         -3,
+		selfDimer:       -9,
+		crossDimer:      -9,
+		minProduct:      100,
+		maxProduct:      300,
+		maxPairs:        5,
+		conditions:      make([]Condition, 0),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Design finds the pairs of primers that meet every constraint and amplify the whole
+// half-open target of a template without overlapping it. Pairs are ranked by penalty,
+// the distance of each primer from the optimal length and melting temperature plus the
+// difference between their melting temperatures, lowest first. There are no pairs if
+// none meet the constraints.
+func (d *Designer) Design(template *immutable.Dna, start, end int) ([]Pair, error) {
+	c, err := newConditions(d.conditions)
+	if err != nil {
+		return nil, err
+	}
+	if d.minLength < 2 || d.minLength > d.optLength || d.optLength > d.maxLength {
+		return nil, fmt.Errorf("primer lengths [%d, %d, %d] are impossible", d.minLength, d.optLength, d.maxLength)
+	}
+	if d.maxPairs < 1 {
+		return nil, fmt.Errorf("number of pairs [%d] must be positive", d.maxPairs)
+	}
+	if d.gcClamp < 0 || d.gcClamp > d.minLength {
+		return nil, fmt.Errorf("GC clamp [%d] must be between 0 and the shortest primer [%d]", d.gcClamp, d.minLength)
+	}
+	if d.maxPolyX < 1 {
+		return nil, fmt.Errorf("longest run of one base [%d] must be positive", d.maxPolyX)
+	}
+	if d.minGC > d.maxGC {
+		return nil, fmt.Errorf("GC contents [%g, %g] are impossible", d.minGC, d.maxGC)
+	}
+	if d.minProduct > d.maxProduct {
+		return nil, fmt.Errorf("product sizes [%d, %d] are impossible", d.minProduct, d.maxProduct)
+	}
+	seq := strings.ToUpper(template.String())
+	if start < 0 || start >= end || end > len(seq) {
+		return nil, fmt.Errorf("requested impossible target [%d:%d]", start, end)
+	}
+
+	var fs, rs []Primer
+	for i := maxInt(0, end-d.maxProduct); i+d.minLength <= start; i++ {
+		for l := d.minLength; l <= d.maxLength && i+l <= start; l++ {
+			if p, ok, err := d.primer(c, seq[i:i+l], i, i+l, '+'); err != nil {
+				return nil, err
+			} else if ok {
+				fs = append(fs, p)
+			}
+		}
+	}
+	for j := minInt(len(seq), start+d.maxProduct); j-d.minLength >= end; j-- {
+		for l := d.minLength; l <= d.maxLength && j-l >= end; l++ {
+			if p, ok, err := d.primer(c, revComp(seq[j-l:j]), j-l, j, '-'); err != nil {
+				return nil, err
+			} else if ok {
+				rs = append(rs, p)
+			}
+		}
+	}
+	return d.pairs(c, fs, rs)
+}
+
+// primer is the candidate primer seq binding [start, end) if it meets the constraints
+func (d *Designer) primer(c *conditions, seq string, start, end int, strand byte) (Primer, bool, error) {
+	p := Primer{Start: start, End: end, Strand: strand}
+	gc := strings.Count(seq, "G") + strings.Count(seq, "C")
+	if p.GC = float64(gc) / float64(len(seq)); p.GC < d.minGC || p.GC > d.maxGC {
+		return p, false, nil
+	}
+	if strings.Trim(seq[len(seq)-d.gcClamp:], "GC") != "" {
+		return p, false, nil
+	}
+	if polyX(seq) > d.maxPolyX {
+		return p, false, nil
+	}
+	var err error
+	if p.Tm, err = c.tm(seq); err != nil || p.Tm < d.minTm || p.Tm > d.maxTm {
+		return p, false, err
+	}
+	if p.Hairpin, err = c.hairpin(seq); err != nil || p.Hairpin < d.hairpin {
+		return p, false, err
+	}
+	if p.SelfDimer, err = c.crossDimer(seq, seq); err != nil || p.SelfDimer < d.selfDimer {
+		return p, false, err
+	}
+	if p.Sequence, err = immutable.NewDna(seq); err != nil {
+		return p, false, err
+	}
+	p.Penalty = math.Abs(p.Tm-d.optTm) + math.Abs(float64(len(seq)-d.optLength))
+	return p, true, nil
+}
+
+// pairs are the best pairs of forward and reverse primers. As the penalty of a pair is
+// at least the sum of the penalties of its primers, primers are tried from the lowest
+// penalty until no later pair could be ranked.
+func (d *Designer) pairs(c *conditions, fs, rs []Primer) ([]Pair, error) {
+	byPenalty := func(ps []Primer) {
+		sort.SliceStable(ps, func(i, j int) bool { return ps[i].Penalty < ps[j].Penalty })
+	}
+	byPenalty(fs)
+	byPenalty(rs)
+	less := func(a, b Pair) bool {
+		if a.Penalty != b.Penalty {
+			return a.Penalty < b.Penalty
+		}
+		if a.Forward.Start != b.Forward.Start || a.Forward.End != b.Forward.End {
+			return a.Forward.Start < b.Forward.Start || a.Forward.Start == b.Forward.Start && a.Forward.End < b.Forward.End
+		}
+		return a.Reverse.End < b.Reverse.End || a.Reverse.End == b.Reverse.End && a.Reverse.Start > b.Reverse.Start
+	}
+	best := make([]Pair, 0, d.maxPairs)
+	full := func(penalty float64) bool {
+		return len(best) == d.maxPairs && penalty > best[len(best)-1].Penalty
+	}
+	for _, f := range fs {
+		if len(rs) == 0 || full(f.Penalty+rs[0].Penalty) {
+			break
+		}
+		for _, r := range rs {
+			if full(f.Penalty + r.Penalty) {
+				break
+			}
+			size := r.End - f.Start
+			if size < d.minProduct || size > d.maxProduct || math.Abs(f.Tm-r.Tm) > d.maxTmDifference {
+				continue
+			}
+			p := Pair{Forward: f, Reverse: r, Product: size}
+			p.Penalty = f.Penalty + r.Penalty + math.Abs(f.Tm-r.Tm)
+			if len(best) == d.maxPairs && !less(p, best[len(best)-1]) {
+				continue
+			}
+			var err error
+			if p.CrossDimer, err = c.crossDimer(f.Sequence.String(), r.Sequence.String()); err != nil {
+				return nil, err
+			} else if p.CrossDimer < d.crossDimer {
+				continue
+			}
+			i := sort.Search(len(best), func(i int) bool { return less(p, best[i]) })
+			if len(best) < d.maxPairs {
+				best = append(best, Pair{})
+			}
+			copy(best[i+1:], best[i:])
+			best[i] = p
+		}
+	}
+	return best, nil
+}
+
+// polyX is the longest run of one base in seq
+func polyX(seq string) int {
+	longest, run := 0, 0
+	for i := 0; i < len(seq); i++ {
+		if i > 0 && seq[i] == seq[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// revComp is the reverse complement of an unambiguous seq
+func revComp(seq string) string {
+	r := make([]byte, len(seq))
+	for i := 0; i < len(seq); i++ {
+		r[len(seq)-1-i] = complement[seq[i]]
+	}
+	return string(r)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,173 @@
+package primer_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/design/primer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// template is a random template of n bases
+func template(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = "ACGT"[r.Intn(4)]
+	}
+	return string(b)
+}
+
+// candidates are the primers of length 18 to 22 binding outside [start, end) which meet
+// the constraints of testOptions
+func candidates(t *testing.T, seq string, start, end int) (fs, rs []primer.Primer) {
+	check := func(s string, st, sp int, strand byte) {
+		gc := float64(strings.Count(s, "G")+strings.Count(s, "C")) / float64(len(s))
+		last := s[len(s)-1]
+		if gc < 0.3 || gc > 0.7 || last != 'G' && last != 'C' {
+			return
+		}
+		for _, b := range []string{"AAAAA", "CCCCC", "GGGGG", "TTTTT"} {
+			if strings.Contains(s, b) {
+				return
+			}
+		}
+		tm, err := primer.Tm(immutable.TestDnaIupac(s))
+		hp, errH := primer.Hairpin(immutable.TestDnaIupac(s))
+		sd, errS := primer.SelfDimer(immutable.TestDnaIupac(s))
+		if err != nil || errH != nil || errS != nil {
+			t.Fatal(err, errH, errS)
+		}
+		if tm < 57 || tm > 63 || hp < -3 || sd < -9 {
+			return
+		}
+		p := primer.Primer{Start: st, End: sp, Strand: strand, Tm: tm, GC: gc, Hairpin: hp, SelfDimer: sd}
+		p.Sequence = immutable.TestDna(s)
+		p.Penalty = math.Abs(tm-60) + math.Abs(float64(len(s)-20))
+		if strand == '+' {
+			fs = append(fs, p)
+		} else {
+			rs = append(rs, p)
+		}
+	}
+	for st := 0; st < len(seq); st++ {
+		for l := 18; l <= 22 && st+l <= len(seq); l++ {
+			if st+l <= start {
+				check(seq[st:st+l], st, st+l, '+')
+			}
+			if st >= end {
+				check(test.RevComp(seq[st:st+l]), st, st+l, '-')
+			}
+		}
+	}
+	return fs, rs
+}
+
+// testOptions are the settings used to test Design
+var testOptions = []primer.Option{primer.LengthIs(18, 20, 22), primer.ProductIs(80, 160), primer.MaxPairsIs(10)}
+
+func TestDesign(t *testing.T) {
+	r := rand.New(rand.NewSource(test.Seed))
+	designed := 0
+	for round := 0; round < 10; round++ {
+		seq := template(r, 260)
+		start, end := 120, 140
+		got, err := primer.New(testOptions...).Design(immutable.TestDna(seq), start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var want []primer.Pair
+		fs, rs := candidates(t, seq, start, end)
+		for _, f := range fs {
+			for _, r := range rs {
+				size := r.End - f.Start
+				if size < 80 || size > 160 || math.Abs(f.Tm-r.Tm) > 5 {
+					continue
+				}
+				cd, _ := primer.CrossDimer(f.Sequence, r.Sequence)
+				if cd < -9 {
+					continue
+				}
+				p := primer.Pair{Forward: f, Reverse: r, Product: size, CrossDimer: cd}
+				p.Penalty = f.Penalty + r.Penalty + math.Abs(f.Tm-r.Tm)
+				want = append(want, p)
+			}
+		}
+		sort.SliceStable(want, func(i, j int) bool { return want[i].Penalty < want[j].Penalty })
+		if len(want) > 10 {
+			want = want[:10]
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("Round %d: Want: %d pairs, Got: %d", round, len(want), len(got))
+		}
+		for i := range got {
+			if math.Abs(got[i].Penalty-want[i].Penalty) > 1e-9 {
+				t.Errorf("Round %d pair %d: Want penalty: %v, Got: %v", round, i, want[i].Penalty, got[i].Penalty)
+			}
+			g := got[i]
+			fwd, rev := g.Forward.Sequence.String(), g.Reverse.Sequence.String()
+			if fwd != seq[g.Forward.Start:g.Forward.End] || rev != test.RevComp(seq[g.Reverse.Start:g.Reverse.End]) {
+				t.Errorf("Round %d pair %d: primers %s %s do not bind where they say", round, i, fwd, rev)
+			}
+			if g.Forward.End > start || g.Reverse.Start < end || g.Product != g.Reverse.End-g.Forward.Start {
+				t.Errorf("Round %d pair %d: product [%d:%d] does not surround the target", round, i, g.Forward.Start, g.Reverse.End)
+			}
+		}
+		designed += len(got)
+	}
+	if designed == 0 {
+		t.Error("Want some pairs designed")
+	}
+}
+
+func TestDesignErrors(t *testing.T) {
+	seq := immutable.TestDna(template(rand.New(rand.NewSource(test.Seed)), 200))
+	tt := []struct {
+		name       string
+		opts       []primer.Option
+		start, end int
+	}{
+		{"EmptyTarget", nil, 50, 50},
+		{"TargetPastEnd", nil, 150, 201},
+		{"NegativeTarget", nil, -1, 10},
+		{"Lengths", []primer.Option{primer.LengthIs(20, 18, 25)}, 50, 60},
+		{"Products", []primer.Option{primer.ProductIs(300, 100)}, 50, 60},
+		{"Pairs", []primer.Option{primer.MaxPairsIs(0)}, 50, 60},
+		{"NegativeGCClamp", []primer.Option{primer.GCClampIs(-1)}, 50, 60},
+		{"LongGCClamp", []primer.Option{primer.GCClampIs(19)}, 50, 60},
+		{"PolyX", []primer.Option{primer.MaxPolyXIs(0)}, 50, 60},
+		{"GC", []primer.Option{primer.GCIs(0.7, 0.3)}, 50, 60},
+		{"Salt", []primer.Option{primer.ConditionsAre(primer.NaIs(0))}, 50, 60},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := primer.New(tc.opts...).Design(seq, tc.start, tc.end); err == nil {
+				t.Error("Want an error")
+			}
+		})
+	}
+}
+
+func ExampleDesigner_Design() {
+	seq, _ := immutable.NewDna("" +
+		"GTCAAAATGGTCAATACTTCACAAAGGTTTTGATGTGAAGATCATATTGGAACCGCTATTGCTCCACTTGTGTGTCAGTG" +
+		"GCAACACCAGGAATTGCGTCGATCGAGCTCTGATCGTAGTATCACTGAAGTTCAGGGAGAGGTTCTCGACATGAGATCCG" +
+		"GGACCTGTGTGGGAACTGGCAAAGGGTTATGCCGGCACCACCTCATATCCATTGCAGATGAGGACTTCGTTGCCACCATT" +
+		"GGCATCGTCTGAGAGTACAGCAGCGGGACAAGTTCAAATTACCGTTCCCAATATAGATGCAAAGCCGGTTAGACAGGTAA" +
+		"ATTAATAACTGACGTCGAGACGTTCAAGTAACACACGTCACTACGCCGAAGCTTAATTGGAATGGCACCAAAAACCGACA")
+	d := primer.New(primer.ProductIs(150, 250), primer.MaxPairsIs(3))
+	pairs, _ := d.Design(seq, 180, 220)
+	for _, p := range pairs {
+		fmt.Printf("%s %s %d %.1f %.1f\n", p.Forward.Sequence, p.Reverse.Sequence, p.Product, p.Forward.Tm, p.Reverse.Tm)
+	}
+	// Output:
+	// GGGACCTGTGTGGGAACTGGC AGCTTCGGCGTAGTGACGTGTG 214 59.3 59.5
+	// CCGGGACCTGTGTGGGAACTG AGCTTCGGCGTAGTGACGTGTG 216 59.0 59.5
+	// CGGGACCTGTGTGGGAACTGG AGCTTCGGCGTAGTGACGTGTG 215 59.0 59.5
+}
@@ -0,0 +1,14 @@
+/*
+Package primer calculates the melting temperatures and secondary structures of DNA
+oligos such as PCR primers.
+
+Melting temperatures come from the nearest-neighbor model (see the data/thermo package)
+corrected for the sodium, magnesium, and dNTPs of the reaction, or from the basic Wallace
+and GC content rules. Free energies of hairpins and dimers are of the most stable
+perfectly paired structure, so they flag primers likely to fold or pair with each other.
+
+A Designer finds pairs of primers that amplify a target of a template, meeting
+constraints on length, melting temperature, GC content, and secondary structures, and
+ranks them by a penalty in the spirit of Primer3.
+*/
+package primer
@@ -0,0 +1,146 @@
+package primer
+
+import (
+	"fmt"
+
+	"github.com/sembio/go/bio/data/thermo"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// unambiguous is the sequence of s in upper case, which must be only A, C, G, and T
+func unambiguous(s sequence.Interface) (string, error) {
+	seq, err := oligo(s)
+	if err != nil {
+		return "", err
+	}
+	for i := 0; i < len(seq); i++ {
+		if _, ok := complement[seq[i]]; !ok {
+			return "", fmt.Errorf("%q at position %d is not A, C, G, or T", seq[i], i)
+		}
+	}
+	return seq, nil
+}
+
+// pairs is whether x and y form a Watson-Crick pair
+func pairs(x, y byte) bool {
+	return complement[x] == y
+}
+
+// Hairpin is the free energy in kcal/mol of the most stable perfectly paired hairpin an
+// oligo folds into, or 0 if it has none more stable than unfolded
+func Hairpin(s sequence.Interface, cs ...Condition) (float64, error) {
+	c, err := newConditions(cs)
+	if err != nil {
+		return 0, err
+	}
+	seq, err := unambiguous(s)
+	if err != nil {
+		return 0, err
+	}
+	return c.hairpin(seq)
+}
+
+// hairpin is the free energy of the most stable hairpin of an unambiguous oligo
+func (c *conditions) hairpin(seq string) (float64, error) {
+	best := 0.0
+	for p := 0; p < len(seq); p++ {
+		for q := p + 4; q < len(seq); q++ {
+			if !pairs(seq[p], seq[q]) {
+				continue
+			}
+			loop, ok := c.table.HairpinLoop(q - p - 1)
+			if !ok {
+				return 0, fmt.Errorf("no parameters for a hairpin loop of %d", q-p-1)
+			}
+			stem := loop
+			for n := 1; p-n >= 0 && q+n < len(seq) && pairs(seq[p-n], seq[q+n]); n++ {
+				st, ok := c.table.Stack(seq[p-n : p-n+2])
+				if !ok {
+					return 0, fmt.Errorf("no parameters for the %s stack", seq[p-n:p-n+2])
+				}
+				stem = stem.Add(st)
+				if g := c.salt(stem, n).G(c.temperature); g < best {
+					best = g
+				}
+			}
+		}
+	}
+	return best, nil
+}
+
+// SelfDimer is the free energy in kcal/mol of the most stable perfectly paired duplex
+// of an oligo with another copy of itself, or 0 if it has none
+func SelfDimer(s sequence.Interface, cs ...Condition) (float64, error) {
+	return CrossDimer(s, s, cs...)
+}
+
+// CrossDimer is the free energy in kcal/mol of the most stable perfectly paired duplex
+// of two oligos, or 0 if they have none
+func CrossDimer(a, b sequence.Interface, cs ...Condition) (float64, error) {
+	c, err := newConditions(cs)
+	if err != nil {
+		return 0, err
+	}
+	x, err := unambiguous(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := unambiguous(b)
+	if err != nil {
+		return 0, err
+	}
+	return c.crossDimer(x, y)
+}
+
+// crossDimer is the free energy of the most stable duplex of two unambiguous oligos
+func (c *conditions) crossDimer(x, y string) (float64, error) {
+	best := 0.0
+	// x[i+t] pairs with y[j-t] along each antiparallel diagonal
+	for d := 0; d < len(x)+len(y)-1; d++ {
+		i, j := 0, d
+		if d >= len(y) {
+			i, j = d-len(y)+1, len(y)-1
+		}
+		for i < len(x) && j >= 0 {
+			if !pairs(x[i], y[j]) {
+				i, j = i+1, j-1
+				continue
+			}
+			start := i
+			for i < len(x) && j >= 0 && pairs(x[i], y[j]) {
+				i, j = i+1, j-1
+			}
+			if i-start < 2 {
+				continue
+			}
+			g, err := c.duplex(x[start:i])
+			if err != nil {
+				return 0, err
+			}
+			if g < best {
+				best = g
+			}
+		}
+	}
+	return best, nil
+}
+
+// duplex is the free energy of seq paired with its complement
+func (c *conditions) duplex(seq string) (float64, error) {
+	var p thermo.Params
+	for _, end := range []byte{seq[0], seq[len(seq)-1]} {
+		q, ok := c.table.Init(end)
+		if !ok {
+			return 0, fmt.Errorf("no initiation parameters for %c", end)
+		}
+		p = p.Add(q)
+	}
+	for i := 0; i+1 < len(seq); i++ {
+		q, ok := c.table.Stack(seq[i : i+2])
+		if !ok {
+			return 0, fmt.Errorf("no parameters for the %s stack", seq[i:i+2])
+		}
+		p = p.Add(q)
+	}
+	return c.salt(p, len(seq)-1).G(c.temperature), nil
+}
@@ -0,0 +1,92 @@
+package primer_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/design/primer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestHairpin(t *testing.T) {
+	tt := []struct {
+		seq  string
+		want float64
+	}{
+		{"GCGCTTTTGCGC", -2.10},
+		{"gcgcttttgcgc", -2.10},
+		{"AAAAAAAAAAAA", 0},
+	}
+	for _, tc := range tt {
+		t.Run(tc.seq, func(t *testing.T) {
+			got, err := primer.Hairpin(immutable.New(tc.seq))
+			if err != nil || math.Abs(got-tc.want) > 0.005 {
+				t.Errorf("Want: %.2f, Got: %.2f (%v)", tc.want, got, err)
+			}
+		})
+	}
+	if _, err := primer.Hairpin(immutable.TestDnaIupac("GCGCNTTTGCGC")); err == nil {
+		t.Error("Want an error for an ambiguous base")
+	}
+}
+
+func TestSelfDimer(t *testing.T) {
+	tt := []struct {
+		seq  string
+		want float64
+	}{
+		{"CGCG", -3.60},
+		{"AAAAAAAAAA", 0},
+	}
+	for _, tc := range tt {
+		t.Run(tc.seq, func(t *testing.T) {
+			got, err := primer.SelfDimer(immutable.TestDnaIupac(tc.seq))
+			if err != nil || math.Abs(got-tc.want) > 0.005 {
+				t.Errorf("Want: %.2f, Got: %.2f (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestCrossDimer(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("CrossDimer is the same either way round",
+		prop.ForAll(
+			func(a, b string) bool {
+				ab, errA := primer.CrossDimer(immutable.TestDnaIupac(a), immutable.TestDnaIupac(b))
+				ba, errB := primer.CrossDimer(immutable.TestDnaIupac(b), immutable.TestDnaIupac(a))
+				return errA == nil && errB == nil && ab <= 0 && math.Abs(ab-ba) < 1e-9
+			},
+			gen.RegexMatch("[ACGT]{1,30}"),
+			gen.RegexMatch("[ACGT]{1,30}"),
+		),
+	)
+	properties.Property("CrossDimer with the reverse complement is at least as stable as with a part",
+		prop.ForAll(
+			func(s string, n int) bool {
+				full, _ := primer.CrossDimer(immutable.TestDnaIupac(s), immutable.TestDnaIupac(test.RevComp(s)))
+				part, _ := primer.CrossDimer(immutable.TestDnaIupac(s), immutable.TestDnaIupac(test.RevComp(s[n%len(s):])))
+				return full <= part+1e-9
+			},
+			gen.RegexMatch("[ACGT]{10,30}"),
+			gen.IntRange(0, 30),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func ExampleCrossDimer() {
+	fwd := immutable.TestDnaIupac("AGCGGATAACAATTTCACAC")
+	rev := immutable.TestDnaIupac("GTGAAATTGTTATCCGCT")
+	g, _ := primer.CrossDimer(fwd, rev)
+	fmt.Printf("%.1f kcal/mol\n", g)
+	// Output:
+	// -14.6 kcal/mol
+}
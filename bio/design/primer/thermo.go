@@ -0,0 +1,272 @@
+package primer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/thermo"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// MaxExpansions is the most unambiguous oligos TmBounds expands an ambiguous oligo into
+const MaxExpansions = 4096
+
+// conditions are the conditions of a reaction
+type conditions struct {
+	na          float64 // mM
+	mg          float64 // mM
+	dntp        float64 // mM
+	oligo       float64 // nM
+	temperature float64 // °C
+	table       thermo.Interface
+}
+
+// Condition is a condition of a reaction
+type Condition func(*conditions)
+
+// NaIs sets the concentration of monovalent cations in mM (50 by default)
+func NaIs(mM float64) Condition {
+	return func(c *conditions) {
+		c.na = mM
+	}
+}
+
+// MgIs sets the concentration of Mg2+ in mM (0 by default)
+func MgIs(mM float64) Condition {
+	return func(c *conditions) {
+		c.mg = mM
+	}
+}
+
+// DNTPIs sets the total concentration of dNTPs in mM, which bind Mg2+ (0 by default)
+func DNTPIs(mM float64) Condition {
+	return func(c *conditions) {
+		c.dntp = mM
+	}
+}
+
+// OligoIs sets the concentration of each strand in nM (50 by default)
+func OligoIs(nM float64) Condition {
+	return func(c *conditions) {
+		c.oligo = nM
+	}
+}
+
+// TemperatureIs sets the temperature in °C at which free energies are given (37 by default)
+func TemperatureIs(celsius float64) Condition {
+	return func(c *conditions) {
+		c.temperature = celsius
+	}
+}
+
+// TableIs sets the nearest-neighbor parameters (thermo.SantaLucia1998 by default)
+func TableIs(t thermo.Interface) Condition {
+	return func(c *conditions) {
+		c.table = t
+	}
+}
+
+// newConditions are the default conditions changed by cs
+func newConditions(cs []Condition) (*conditions, error) {
+	c := &conditions{
+		na:          50,
+		oligo:       50,
+		temperature: 37,
+		table:       thermo.SantaLucia1998{},
+	}
+	for _, f := range cs {
+		f(c)
+	}
+	if c.na < 0 || c.mg < 0 || c.dntp < 0 || c.naEq() <= 0 {
+		return nil, fmt.Errorf("salt concentrations must not be negative and some salt is needed")
+	}
+	if c.oligo <= 0 {
+		return nil, fmt.Errorf("oligo concentration [%g nM] must be positive", c.oligo)
+	}
+	return c, nil
+}
+
+// naEq is the concentration of Na+ in M with the same effect as the salts, counting
+// the Mg2+ not bound by dNTPs (von Ahsen et al. 2001)
+func (c *conditions) naEq() float64 {
+	return (c.na + 120*math.Sqrt(math.Max(c.mg-c.dntp, 0))) / 1000
+}
+
+// salt corrects the entropy of a duplex of n stacks for salt (SantaLucia 1998)
+func (c *conditions) salt(p thermo.Params, n int) thermo.Params {
+	return thermo.Params{H: p.H, S: p.S + 0.368*float64(n)*math.Log(c.naEq())}
+}
+
+// oligo is the sequence of s in upper case
+func oligo(s sequence.Interface) (string, error) {
+	seq, err := s.Range(0, s.Length())
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(seq), nil
+}
+
+// Tm is the melting temperature in °C of an oligo and its complement by the
+// nearest-neighbor method. The parameters of ambiguous positions are averaged over the
+// bases they stand for.
+func Tm(s sequence.Interface, cs ...Condition) (float64, error) {
+	c, err := newConditions(cs)
+	if err != nil {
+		return 0, err
+	}
+	seq, err := oligo(s)
+	if err != nil {
+		return 0, err
+	}
+	return c.tm(seq)
+}
+
+// TmBounds are the lowest and highest melting temperatures in °C of the unambiguous
+// oligos an ambiguous oligo stands for, of which there may be up to MaxExpansions
+func TmBounds(s sequence.Interface, cs ...Condition) (float64, float64, error) {
+	c, err := newConditions(cs)
+	if err != nil {
+		return 0, 0, err
+	}
+	seq, err := oligo(s)
+	if err != nil {
+		return 0, 0, err
+	}
+	a := hashmap.NewDnaIupac()
+	expansions := []string{""}
+	for i := 0; i < len(seq); i++ {
+		bases := a.Expand(string(seq[i]))
+		if bases == "" {
+			return 0, 0, fmt.Errorf("%q is not an IUPAC nucleotide", seq[i])
+		}
+		if len(expansions)*len(bases) > MaxExpansions {
+			return 0, 0, fmt.Errorf("oligo stands for more than %d oligos", MaxExpansions)
+		}
+		next := make([]string, 0, len(expansions)*len(bases))
+		for _, e := range expansions {
+			for _, b := range bases {
+				next = append(next, e+string(b))
+			}
+		}
+		expansions = next
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, e := range expansions {
+		tm, err := c.tm(e)
+		if err != nil {
+			return 0, 0, err
+		}
+		lo, hi = math.Min(lo, tm), math.Max(hi, tm)
+	}
+	return lo, hi, nil
+}
+
+// tm is the nearest-neighbor melting temperature of an upper case oligo
+func (c *conditions) tm(seq string) (float64, error) {
+	if len(seq) < 2 {
+		return 0, fmt.Errorf("oligo %q is too short to melt", seq)
+	}
+	a := hashmap.NewDnaIupac()
+	var p thermo.Params
+	for _, end := range []byte{seq[0], seq[len(seq)-1]} {
+		bases := a.Expand(string(end))
+		if bases == "" {
+			return 0, fmt.Errorf("%q is not an IUPAC nucleotide", end)
+		}
+		for i := 0; i < len(bases); i++ {
+			q, ok := c.table.Init(bases[i])
+			if !ok {
+				return 0, fmt.Errorf("no initiation parameters for %c", bases[i])
+			}
+			p = p.Add(scale(q, len(bases)))
+		}
+	}
+	for i := 0; i+1 < len(seq); i++ {
+		xs, ys := a.Expand(string(seq[i])), a.Expand(string(seq[i+1]))
+		if xs == "" || ys == "" {
+			return 0, fmt.Errorf("%q is not IUPAC nucleotides", seq[i:i+2])
+		}
+		for _, x := range xs {
+			for _, y := range ys {
+				q, ok := c.table.Stack(string(x) + string(y))
+				if !ok {
+					return 0, fmt.Errorf("no parameters for the %c%c stack", x, y)
+				}
+				p = p.Add(scale(q, len(xs)*len(ys)))
+			}
+		}
+	}
+	strands := 4.0
+	if selfComplementary(seq) {
+		p = p.Add(c.table.Symmetry())
+		strands = 1
+	}
+	p = c.salt(p, len(seq)-1)
+	return p.H*1000/(p.S+thermo.R*math.Log(c.oligo*1e-9/strands)) - thermo.Kelvin, nil
+}
+
+// scale is p divided between n alternatives
+func scale(p thermo.Params, n int) thermo.Params {
+	return thermo.Params{H: p.H / float64(n), S: p.S / float64(n)}
+}
+
+// complement is the complement of each base
+var complement = map[byte]byte{'A': 'T', 'C': 'G', 'G': 'C', 'T': 'A'}
+
+// selfComplementary is whether seq is its own reverse complement
+func selfComplementary(seq string) bool {
+	for i := 0; i < len(seq); i++ {
+		if c, ok := complement[seq[i]]; !ok || c != seq[len(seq)-1-i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TmWallace is the melting temperature in °C of an oligo by the Wallace rule, 2 °C for
+// each A or T and 4 °C for each G or C, for oligos of 14 bases or fewer. Ambiguous
+// positions count their fraction of G and C.
+func TmWallace(s sequence.Interface) (float64, error) {
+	seq, err := oligo(s)
+	if err != nil {
+		return 0, err
+	}
+	gc, err := gcCount(seq)
+	if err != nil {
+		return 0, err
+	}
+	return 2*(float64(len(seq))-gc) + 4*gc, nil
+}
+
+// TmGC is the melting temperature in °C of an oligo from its GC content,
+// 64.9 + 41 (GC - 16.4) / N, for oligos longer than 13 bases
+func TmGC(s sequence.Interface) (float64, error) {
+	seq, err := oligo(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(seq) == 0 {
+		return 0, fmt.Errorf("empty oligo")
+	}
+	gc, err := gcCount(seq)
+	if err != nil {
+		return 0, err
+	}
+	return 64.9 + 41*(gc-16.4)/float64(len(seq)), nil
+}
+
+// gcCount is the expected number of G and C in seq
+func gcCount(seq string) (float64, error) {
+	a := hashmap.NewDnaIupac()
+	gc := 0.0
+	for i := 0; i < len(seq); i++ {
+		bases := a.Expand(string(seq[i]))
+		if bases == "" {
+			return 0, fmt.Errorf("%q is not an IUPAC nucleotide", seq[i])
+		}
+		gc += float64(strings.Count(bases, "G")+strings.Count(bases, "C")) / float64(len(bases))
+	}
+	return gc, nil
+}
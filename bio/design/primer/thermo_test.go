@@ -0,0 +1,147 @@
+package primer_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/design/primer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestTm(t *testing.T) {
+	tt := []struct {
+		name string
+		seq  string
+		cs   []primer.Condition
+		want float64
+	}{
+		{"Default", "AGCGGATAACAATTTCACAC", nil, 48.62},
+		{"Magnesium", "AGCGGATAACAATTTCACAC", []primer.Condition{primer.MgIs(1.5), primer.DNTPIs(0.8)}, 53.88},
+		{"LowerCase", "agcggataacaatttcacac", nil, 48.62},
+		{"Ambiguous", "AGCGGATAACAATTTCACAN", nil, 48.04},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := primer.Tm(immutable.New(tc.seq), tc.cs...)
+			if err != nil || math.Abs(got-tc.want) > 0.005 {
+				t.Errorf("Want: %.2f, Got: %.2f (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestTmErrors(t *testing.T) {
+	tt := []struct {
+		name string
+		seq  string
+		cs   []primer.Condition
+	}{
+		{"TooShort", "A", nil},
+		{"NoSalt", "ACGTACGT", []primer.Condition{primer.NaIs(0)}},
+		{"NegativeSalt", "ACGTACGT", []primer.Condition{primer.MgIs(-1)}},
+		{"NoOligo", "ACGTACGT", []primer.Condition{primer.OligoIs(0)}},
+		{"Gap", "ACGT-ACGT", nil},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := primer.Tm(immutable.New(tc.seq), tc.cs...); err == nil {
+				t.Error("Want an error")
+			}
+		})
+	}
+}
+
+func TestTmProperties(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Tm is the same for the reverse complement",
+		prop.ForAll(
+			func(s string) bool {
+				a, errA := primer.Tm(immutable.TestDnaIupac(s))
+				b, errB := primer.Tm(immutable.TestDnaIupac(test.RevComp(s)))
+				return errA == nil && errB == nil && math.Abs(a-b) < 1e-9
+			},
+			gen.RegexMatch("[ACGT]{2,40}"),
+		),
+	)
+	properties.Property("Tm rises with salt and oligo concentration",
+		prop.ForAll(
+			func(s string) bool {
+				low, _ := primer.Tm(immutable.TestDnaIupac(s))
+				salt, _ := primer.Tm(immutable.TestDnaIupac(s), primer.NaIs(100))
+				mg, _ := primer.Tm(immutable.TestDnaIupac(s), primer.MgIs(2))
+				oligo, _ := primer.Tm(immutable.TestDnaIupac(s), primer.OligoIs(500))
+				return salt > low && mg > low && oligo > low
+			},
+			gen.RegexMatch("[ACGT]{8,40}"),
+		),
+	)
+	properties.Property("TmBounds bracket Tm and are Tm when unambiguous",
+		prop.ForAll(
+			func(s string) bool {
+				tm, err := primer.Tm(immutable.TestDnaIupac(s))
+				lo, hi, errB := primer.TmBounds(immutable.TestDnaIupac(s))
+				if err != nil || errB != nil || lo > tm+1e-9 || tm > hi+1e-9 {
+					return false
+				}
+				for i := 0; i < len(s); i++ {
+					if s[i] != 'A' && s[i] != 'C' && s[i] != 'G' && s[i] != 'T' {
+						return true
+					}
+				}
+				return math.Abs(lo-tm) < 1e-9 && math.Abs(hi-tm) < 1e-9
+			},
+			gen.RegexMatch("[ACGT]{6,20}[ACGTRYN]{0,4}"),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestTmBoundsTooAmbiguous(t *testing.T) {
+	if _, _, err := primer.TmBounds(immutable.TestDnaIupac("NNNNNNNNNNNNNNNNNNNN")); err == nil {
+		t.Error("Want an error")
+	}
+}
+
+func TestTmWallace(t *testing.T) {
+	tt := []struct {
+		seq  string
+		want float64
+	}{
+		{"ACGTACGT", 24},
+		{"GGGG", 16},
+		{"AAAS", 10},
+		{"AAAN", 9},
+	}
+	for _, tc := range tt {
+		t.Run(tc.seq, func(t *testing.T) {
+			if got, err := primer.TmWallace(immutable.TestDnaIupac(tc.seq)); err != nil || got != tc.want {
+				t.Errorf("Want: %v, Got: %v (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestTmGC(t *testing.T) {
+	got, err := primer.TmGC(immutable.TestDnaIupac("AGCGGATAACAATTTCACAC"))
+	if want := 64.9 + 41*(8-16.4)/20; err != nil || math.Abs(got-want) > 1e-9 {
+		t.Errorf("Want: %v, Got: %v (%v)", want, got, err)
+	}
+	if _, err := primer.TmGC(immutable.New("ACGT-")); err == nil {
+		t.Error("Want an error for a gap")
+	}
+}
+
+func ExampleTm() {
+	m13, _ := immutable.NewDna("AGCGGATAACAATTTCACAC")
+	tm, _ := primer.Tm(m13, primer.MgIs(1.5), primer.DNTPIs(0.8), primer.OligoIs(200))
+	fmt.Printf("%.1f °C\n", tm)
+	// Output:
+	// 55.8 °C
+}
@@ -0,0 +1,68 @@
+package debruijn
+
+import (
+	"github.com/sembio/go/bio/kmer"
+)
+
+// RemoveTips removes dead-end unitigs of up to maxKmers k-mers that branch off the
+// rest of the graph, most of which come from errors near the ends of reads. It returns
+// the number of unitigs removed; removing tips can expose new ones.
+func (g *Graph) RemoveTips(maxKmers uint) int {
+	removed := 0
+	for _, u := range g.Unitigs() {
+		if u.kmers > maxKmers {
+			continue
+		}
+		in, out := len(g.Predecessors(u.first)), len(g.Successors(u.last))
+		if (in == 0) != (out == 0) {
+			g.remove(u)
+			removed++
+		}
+	}
+	return removed
+}
+
+// PopBubbles collapses simple bubbles, unitigs of up to maxKmers k-mers that leave and
+// rejoin the graph at the same k-mers, keeping the best covered unitig of each bubble.
+// Most bubbles come from errors in the middle of reads or from heterozygous variants.
+// It returns the number of unitigs removed.
+func (g *Graph) PopBubbles(maxKmers uint) int {
+	type key struct{ from, to kmer.Kmer }
+	best := make(map[key]Unitig)
+	removed := 0
+	for _, u := range g.Unitigs() {
+		if u.kmers > maxKmers {
+			continue
+		}
+		prev, next := g.Predecessors(u.first), g.Successors(u.last)
+		if len(prev) != 1 || len(next) != 1 {
+			continue
+		}
+		k := key{prev[0], next[0]}
+		if rc := (key{kmer.RevComp(next[0], g.k), kmer.RevComp(prev[0], g.k)}); rc.from < k.from ||
+			rc.from == k.from && rc.to < k.to {
+			k = rc
+		}
+		other, ok := best[k]
+		switch {
+		case !ok:
+			best[k] = u
+		case u.Coverage > other.Coverage:
+			g.remove(other)
+			best[k] = u
+			removed++
+		default:
+			g.remove(u)
+			removed++
+		}
+	}
+	return removed
+}
+
+// remove removes the k-mers of unitig u from the graph
+func (g *Graph) remove(u Unitig) {
+	it, _ := kmer.NewIterator(u.Sequence, g.k)
+	for it.Next() {
+		delete(g.counts, it.Canonical())
+	}
+}
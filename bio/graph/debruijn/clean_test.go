@@ -0,0 +1,46 @@
+package debruijn_test
+
+import (
+	"testing"
+
+	"github.com/sembio/go/bio/graph/debruijn"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestRemoveTips(t *testing.T) {
+	genome := test.RandomStringFromRunes(test.Seed, 1000, []rune("ACGT"))
+	reads := tile(genome, 100, 10)
+	for i := 0; i < 3; i++ {
+		bad := []byte(genome[300:400])
+		bad[95] = substitute(bad[95])
+		reads = append(reads, immutable.TestDna(string(bad)))
+	}
+	g, _ := debruijn.New(reads, 21, debruijn.MinCoverageIs(2))
+	if n := len(g.Unitigs()); n != 3 {
+		t.Errorf("Want: a tip splitting the graph into 3 unitigs, Got: %d", n)
+	}
+	if n := g.RemoveTips(10); n != 1 {
+		t.Errorf("Want: 1 tip removed, Got: %d", n)
+	}
+	single(t, g, genome)
+}
+
+func TestPopBubbles(t *testing.T) {
+	genome := test.RandomStringFromRunes(test.Seed, 1000, []rune("ACGT"))
+	variant := []byte(genome)
+	variant[500] = substitute(variant[500])
+	reads := append(tile(genome, 100, 10), tile(string(variant), 100, 30)...)
+
+	g, _ := debruijn.New(reads, 21, debruijn.MinCoverageIs(2))
+	if n := len(g.Unitigs()); n != 4 {
+		t.Errorf("Want: a bubble splitting the graph into 4 unitigs, Got: %d", n)
+	}
+	if n := g.PopBubbles(10); n != 0 {
+		t.Errorf("Want: no bubble popped longer than 10 k-mers, Got: %d", n)
+	}
+	if n := g.PopBubbles(21); n != 1 {
+		t.Errorf("Want: 1 bubble popped, Got: %d", n)
+	}
+	single(t, g, genome)
+}
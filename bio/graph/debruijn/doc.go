@@ -0,0 +1,9 @@
+/*
+Package debruijn builds de Bruijn graphs from the k-mers of DNA reads.
+
+Nodes are canonical k-mers, so each node stands for a k-mer and its reverse complement,
+and two k-mers are joined when the last k-1 bases of one are the first k-1 bases of the
+other. K must be odd so that no k-mer is its own reverse complement. Non-branching paths
+compact into unitigs, which can be written as segments of a GFA1 graph.
+*/
+package debruijn
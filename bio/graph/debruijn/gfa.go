@@ -0,0 +1,33 @@
+package debruijn
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteGFA writes the unitigs of the graph and the links between them as GFA1, naming
+// each segment by its place in Unitigs (from 1) and tagging it with its length (LN)
+// and total k-mer coverage (KC)
+func (g *Graph) WriteGFA(w io.Writer) error {
+	us := g.Unitigs()
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "H\tVN:Z:1.0\n")
+	for i, u := range us {
+		seq, _ := u.Sequence.Range(0, u.Sequence.Length())
+		fmt.Fprintf(out, "S\t%d\t%s\tLN:i:%d\tKC:i:%d\n", i+1, seq, len(seq), u.count)
+	}
+	for _, l := range g.Links(us) {
+		fmt.Fprintf(out, "L\t%d\t%s\t%d\t%s\t%dM\n",
+			l.From+1, orientation(l.FromReverse), l.To+1, orientation(l.ToReverse), g.k-1)
+	}
+	return out.Flush()
+}
+
+// orientation is the GFA orientation of a segment
+func orientation(reverse bool) string {
+	if reverse {
+		return "-"
+	}
+	return "+"
+}
@@ -0,0 +1,27 @@
+package debruijn_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sembio/go/bio/graph/debruijn"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+func ExampleGraph_WriteGFA() {
+	reads := []*immutable.Dna{
+		immutable.TestDna("GATTACAGG"),
+		immutable.TestDna("GATTACATT"),
+	}
+	g, _ := debruijn.New(reads, 5)
+	fmt.Println(g.Len(), "k-mers")
+	g.WriteGFA(os.Stdout)
+	// Output:
+	// 7 k-mers
+	// H	VN:Z:1.0
+	// S	1	AATGTA	LN:i:6	KC:i:2
+	// S	2	TACAGG	LN:i:6	KC:i:2
+	// S	3	GATTACA	LN:i:7	KC:i:6
+	// L	1	+	3	-	4M
+	// L	2	-	3	-	4M
+}
@@ -0,0 +1,122 @@
+package debruijn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Graph is a de Bruijn graph of canonical k-mers and how often they were seen
+type Graph struct {
+	k           uint
+	minCoverage uint
+	counts      map[kmer.Kmer]uint
+}
+
+// Option is a setting of a Graph
+type Option func(*Graph)
+
+// MinCoverageIs drops k-mers seen fewer than n times, most of which are read errors
+// (1 by default, keeping every k-mer)
+func MinCoverageIs(n uint) Option {
+	return func(g *Graph) {
+		g.minCoverage = n
+	}
+}
+
+// New generates the Graph of the k-mers of reads
+func New(reads []*immutable.Dna, k uint, opts ...Option) (*Graph, error) {
+	c, err := kmer.NewCounter(k, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reads {
+		if err := c.Add(r); err != nil {
+			return nil, err
+		}
+	}
+	return FromCounter(c, opts...)
+}
+
+// FromCounter generates the Graph of the k-mers of a canonical Counter, such as one
+// counted in parallel
+func FromCounter(c *kmer.Counter, opts ...Option) (*Graph, error) {
+	if !c.Canonical() {
+		return nil, fmt.Errorf("de Bruijn graphs need canonical k-mer counts")
+	}
+	if c.K()%2 == 0 {
+		return nil, fmt.Errorf("k [%d] must be odd", c.K())
+	}
+	g := &Graph{
+		k:           c.K(),
+		minCoverage: 1,
+		counts:      make(map[kmer.Kmer]uint),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	for _, x := range c.Kmers() {
+		if n := c.Count(x); n >= g.minCoverage {
+			g.counts[x] = n
+		}
+	}
+	return g, nil
+}
+
+// K is the length of the k-mers of the graph
+func (g *Graph) K() uint {
+	return g.k
+}
+
+// Len is the number of canonical k-mers in the graph
+func (g *Graph) Len() int {
+	return len(g.counts)
+}
+
+// Kmers are the canonical k-mers of the graph in order
+func (g *Graph) Kmers() []kmer.Kmer {
+	xs := make([]kmer.Kmer, 0, len(g.counts))
+	for x := range g.counts {
+		xs = append(xs, x)
+	}
+	sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+	return xs
+}
+
+// Coverage is the number of times k-mer x (in either orientation) was seen, or 0 if
+// it is not in the graph
+func (g *Graph) Coverage(x kmer.Kmer) uint {
+	return g.counts[kmer.Canonical(x, g.k)]
+}
+
+// Successors are the k-mers in the graph that follow k-mer x as read, extending it
+// by one base
+func (g *Graph) Successors(x kmer.Kmer) []kmer.Kmer {
+	var next []kmer.Kmer
+	shifted := (uint64(x) << 2) & g.mask()
+	for b := uint64(0); b < 4; b++ {
+		if y := kmer.Kmer(shifted | b); g.Coverage(y) != 0 {
+			next = append(next, y)
+		}
+	}
+	return next
+}
+
+// Predecessors are the k-mers in the graph that precede k-mer x as read
+func (g *Graph) Predecessors(x kmer.Kmer) []kmer.Kmer {
+	var prev []kmer.Kmer
+	shifted := uint64(x) >> 2
+	for b := uint64(0); b < 4; b++ {
+		if y := kmer.Kmer(shifted | b<<(2*(g.k-1))); g.Coverage(y) != 0 {
+			prev = append(prev, y)
+		}
+	}
+	return prev
+}
+
+// mask keeps the lowest 2k bits
+func (g *Graph) mask() uint64 {
+	return (uint64(1) << (2 * g.k)) - 1
+}
@@ -0,0 +1,70 @@
+package debruijn_test
+
+import (
+	"testing"
+
+	"github.com/sembio/go/bio/graph/debruijn"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// substitute is a base other than b
+func substitute(b byte) byte {
+	return map[byte]byte{'A': 'C', 'C': 'G', 'G': 'T', 'T': 'A'}[b]
+}
+
+// tile cuts s into reads of length n starting every step bases, reading each from
+// both strands
+func tile(s string, n, step int) []*immutable.Dna {
+	var reads []*immutable.Dna
+	for i := 0; ; i += step {
+		if i+n > len(s) {
+			i = len(s) - n
+		}
+		reads = append(reads, immutable.TestDna(s[i:i+n]), immutable.TestDna(test.RevComp(s[i:i+n])))
+		if i+n == len(s) {
+			return reads
+		}
+	}
+}
+
+// single checks that g compacts to one unitig reading as want on either strand
+func single(t *testing.T, g *debruijn.Graph, want string) {
+	t.Helper()
+	us := g.Unitigs()
+	if len(us) != 1 {
+		t.Fatalf("Want: 1 unitig, Got: %d", len(us))
+	}
+	if got := us[0].Sequence.String(); got != want && got != test.RevComp(want) {
+		t.Errorf("Want: %s, Got: %s", want, got)
+	}
+}
+
+func TestMinCoverage(t *testing.T) {
+	genome := test.RandomStringFromRunes(test.Seed, 1000, []rune("ACGT"))
+	reads := tile(genome, 100, 10)
+	bad := []byte(genome[200:300])
+	bad[50] = substitute(bad[50])
+	reads = append(reads, immutable.TestDna(string(bad)))
+
+	g, _ := debruijn.New(reads, 21)
+	if n := len(g.Unitigs()); n == 1 {
+		t.Errorf("Want: an erroneous read to branch the graph, Got: %d unitigs", n)
+	}
+	g, _ = debruijn.New(reads, 21, debruijn.MinCoverageIs(2))
+	single(t, g, genome)
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := debruijn.New(nil, 20); err == nil {
+		t.Error("Want: error for even k")
+	}
+	if _, err := debruijn.New(nil, 33); err == nil {
+		t.Error("Want: error for k over kmer.MaxK")
+	}
+	c, _ := kmer.NewCounter(21, false)
+	if _, err := debruijn.FromCounter(c); err == nil {
+		t.Error("Want: error for counts that are not canonical")
+	}
+}
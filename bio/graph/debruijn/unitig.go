@@ -0,0 +1,151 @@
+package debruijn
+
+import (
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Unitig is a maximal non-branching path of k-mers in a Graph
+type Unitig struct {
+	Sequence *immutable.Dna
+	Coverage float64 // mean coverage of the k-mers of the unitig
+
+	first, last kmer.Kmer // the first and last k-mers as read along Sequence
+	kmers       uint
+	count       uint
+}
+
+// Link joins the end of one unitig to the start of another, either of which may be
+// reverse complemented
+type Link struct {
+	From        int
+	FromReverse bool
+	To          int
+	ToReverse   bool
+}
+
+// end is a unitig in one orientation
+type end struct {
+	unitig  int
+	reverse bool
+}
+
+// Unitigs compacts the graph into unitigs, covering every k-mer exactly once
+func (g *Graph) Unitigs() []Unitig {
+	var us []Unitig
+	visited := make(map[kmer.Kmer]bool, len(g.counts))
+	for _, x := range g.Kmers() {
+		if visited[x] {
+			continue
+		}
+		visited[x] = true
+		right, last, rightCount := g.extend(x, visited)
+		left, first, leftCount := g.extend(kmer.RevComp(x, g.k), visited)
+		seq := revComp(left) + kmer.Decode(x, g.k) + string(right)
+		dna, _ := immutable.NewDna(seq)
+		kmers := uint(len(left) + 1 + len(right))
+		count := leftCount + g.counts[x] + rightCount
+		us = append(us, Unitig{
+			Sequence: dna,
+			Coverage: float64(count) / float64(kmers),
+			first:    kmer.RevComp(first, g.k),
+			last:     last,
+			kmers:    kmers,
+			count:    count,
+		})
+	}
+	return us
+}
+
+// extend follows the non-branching path from k-mer x, returning the bases added,
+// the last k-mer reached, and the sum of the coverage of the k-mers added
+func (g *Graph) extend(x kmer.Kmer, visited map[kmer.Kmer]bool) ([]byte, kmer.Kmer, uint) {
+	var bases []byte
+	count := uint(0)
+	for {
+		next := g.Successors(x)
+		if len(next) != 1 || len(g.Predecessors(next[0])) != 1 {
+			break
+		}
+		y := next[0]
+		c := kmer.Canonical(y, g.k)
+		if visited[c] {
+			break
+		}
+		visited[c] = true
+		bases = append(bases, "ACGT"[y&3])
+		count += g.counts[c]
+		x = y
+	}
+	return bases, x, count
+}
+
+// Links are the links between unitigs in the graph, each given in one orientation only
+func (g *Graph) Links(us []Unitig) []Link {
+	starts := make(map[kmer.Kmer]end, 2*len(us))
+	for i, u := range us {
+		starts[u.first] = end{i, false}
+		starts[kmer.RevComp(u.last, g.k)] = end{i, true}
+	}
+	var links []Link
+	for i, u := range us {
+		for _, from := range []end{{i, false}, {i, true}} {
+			x := u.last
+			if from.reverse {
+				x = kmer.RevComp(u.first, g.k)
+			}
+			for _, y := range g.Successors(x) {
+				to, ok := starts[y]
+				if !ok {
+					continue
+				}
+				l := Link{from.unitig, from.reverse, to.unitig, to.reverse}
+				if !less(mirror(l), l) {
+					links = append(links, l)
+				}
+			}
+		}
+	}
+	return links
+}
+
+// mirror is the same link read along the opposite strand
+func mirror(l Link) Link {
+	return Link{l.To, !l.ToReverse, l.From, !l.FromReverse}
+}
+
+// less orders links by their ends
+func less(a, b Link) bool {
+	if a.From != b.From {
+		return a.From < b.From
+	}
+	if a.FromReverse != b.FromReverse {
+		return !a.FromReverse
+	}
+	if a.To != b.To {
+		return a.To < b.To
+	}
+	return !a.ToReverse && b.ToReverse
+}
+
+// revComp is the reverse complement of bases
+func revComp(bases []byte) string {
+	out := make([]byte, len(bases))
+	for i, b := range bases {
+		out[len(bases)-1-i] = "TGCA"[code(b)]
+	}
+	return string(out)
+}
+
+// code is the 2-bit code of base b
+func code(b byte) int {
+	switch b {
+	case 'C':
+		return 1
+	case 'G':
+		return 2
+	case 'T':
+		return 3
+	}
+	return 0
+}
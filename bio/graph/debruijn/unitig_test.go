@@ -0,0 +1,85 @@
+package debruijn_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/graph/debruijn"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestUnitigs(t *testing.T) {
+	genome := test.RandomStringFromRunes(test.Seed, 2000, []rune("ACGT"))
+	g, err := debruijn.New(tile(genome, 100, 10), 31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single(t, g, genome)
+	if u := g.Unitigs()[0]; u.Coverage < 10 || u.Coverage > 22 {
+		t.Errorf("Want: coverage of about 20, Got: %f", u.Coverage)
+	}
+	if links := g.Links(g.Unitigs()); len(links) != 0 {
+		t.Errorf("Want: no links, Got: %v", links)
+	}
+}
+
+func TestUnitigsCover(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Unitigs cover each k-mer once and links join overlapping ends",
+		prop.ForAll(
+			func(n uint) bool {
+				const k = 5
+				seq := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGT"))
+				g, _ := debruijn.New([]*immutable.Dna{immutable.TestDna(seq)}, k)
+				seen := make(map[kmer.Kmer]bool)
+				us := g.Unitigs()
+				for _, u := range us {
+					it, _ := kmer.NewIterator(u.Sequence, k)
+					for it.Next() {
+						if seen[it.Canonical()] || g.Coverage(it.Kmer()) == 0 {
+							return false
+						}
+						seen[it.Canonical()] = true
+					}
+				}
+				if len(seen) != g.Len() {
+					return false
+				}
+				oriented := func(i int, reverse bool) string {
+					if reverse {
+						return test.RevComp(us[i].Sequence.String())
+					}
+					return us[i].Sequence.String()
+				}
+				for _, l := range g.Links(us) {
+					from, to := oriented(l.From, l.FromReverse), oriented(l.To, l.ToReverse)
+					if from[len(from)-k+1:] != to[:k-1] {
+						return false
+					}
+				}
+				return true
+			},
+			gen.UIntRange(0, 300),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestCircular(t *testing.T) {
+	genome := test.RandomStringFromRunes(test.Seed, 500, []rune("ACGT"))
+	g, _ := debruijn.New([]*immutable.Dna{immutable.TestDna(genome + genome[:20])}, 21)
+	us := g.Unitigs()
+	if len(us) != 1 || us[0].Sequence.Length() != 520 {
+		t.Fatalf("Want: 1 unitig around the circle, Got: %d", len(us))
+	}
+	links := g.Links(us)
+	if len(links) != 1 || links[0].From != 0 || links[0].To != 0 || links[0].FromReverse != links[0].ToReverse {
+		t.Errorf("Want: the unitig to link to itself, Got: %v", links)
+	}
+}
@@ -0,0 +1,11 @@
+/*
+Package index finds exact matches of patterns in sequences with suffix arrays and
+FM-indexes.
+
+SuffixArray sorts the suffixes of a text in linear time with SA-IS. An Index holds the
+Burrows-Wheeler transform (BWT) of one or more sequences along with a sample of their
+suffix array, so it counts the matches of a pattern in time proportional to the length
+of the pattern and locates each match in a number of steps bounded by the sample rate.
+Indexes can be written to disk and read back so they are built once per reference.
+*/
+package index
@@ -0,0 +1,123 @@
+package index
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// magic begins every serialized Index
+const magic = "BFMI"
+
+// version is the version of the serialized format
+const version = 1
+
+// counter counts the bytes written through it
+type counter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *counter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// WriteTo writes the Index in a binary format: a header followed by the starts of the
+// sequences, the alphabet, the BWT, and the sampled suffix array. Counts are rebuilt
+// when it is read.
+func (x *Index) WriteTo(w io.Writer) (int64, error) {
+	c := &counter{w: w}
+	out := bufio.NewWriter(c)
+	out.WriteString(magic)
+	out.WriteByte(version)
+	for _, v := range []uint64{uint64(x.rate), uint64(len(x.starts)), uint64(len(x.alphabet)), uint64(len(x.bwt))} {
+		binary.Write(out, binary.LittleEndian, v)
+	}
+	for _, s := range x.starts {
+		binary.Write(out, binary.LittleEndian, uint64(s))
+	}
+	out.Write(x.alphabet)
+	out.Write(x.bwt)
+	binary.Write(out, binary.LittleEndian, x.sampled)
+	binary.Write(out, binary.LittleEndian, uint64(len(x.samples)))
+	binary.Write(out, binary.LittleEndian, x.samples)
+	err := out.Flush()
+	return c.n, err
+}
+
+// ReadIndex reads an Index written by WriteTo
+func ReadIndex(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+	head := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, head); err != nil {
+		return nil, err
+	}
+	if string(head[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not an index")
+	}
+	if head[len(magic)] != version {
+		return nil, fmt.Errorf("unknown index version: %d", head[len(magic)])
+	}
+	var sizes [4]uint64
+	if err := binary.Read(br, binary.LittleEndian, &sizes); err != nil {
+		return nil, err
+	}
+	rate, nStarts, nAlphabet, nBwt := sizes[0], sizes[1], sizes[2], sizes[3]
+	if rate < 1 || nAlphabet > 254 || nBwt == 0 || nBwt >= 1<<31 || nStarts > nBwt {
+		return nil, fmt.Errorf("corrupt index header")
+	}
+
+	x := &Index{
+		rate:     int(rate),
+		starts:   make([]int, nStarts),
+		alphabet: make([]byte, nAlphabet),
+		bwt:      make([]byte, nBwt),
+		sampled:  make([]uint64, (nBwt+63)/64),
+	}
+	starts := make([]uint64, nStarts)
+	if err := binary.Read(br, binary.LittleEndian, starts); err != nil {
+		return nil, err
+	}
+	for i, s := range starts {
+		x.starts[i] = int(s)
+	}
+	if _, err := io.ReadFull(br, x.alphabet); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(br, x.bwt); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(br, binary.LittleEndian, x.sampled); err != nil {
+		return nil, err
+	}
+	var nSamples uint64
+	if err := binary.Read(br, binary.LittleEndian, &nSamples); err != nil {
+		return nil, err
+	}
+	if nSamples > nBwt {
+		return nil, fmt.Errorf("corrupt index samples")
+	}
+	x.samples = make([]int32, nSamples)
+	if err := binary.Read(br, binary.LittleEndian, x.samples); err != nil {
+		return nil, err
+	}
+	sampled := 0
+	for _, w := range x.sampled {
+		sampled += bits.OnesCount64(w)
+	}
+	if sampled != len(x.samples) {
+		return nil, fmt.Errorf("corrupt index samples")
+	}
+	for _, b := range x.bwt {
+		if int(b) >= len(x.alphabet)+2 {
+			return nil, fmt.Errorf("corrupt index BWT")
+		}
+	}
+	x.setCodes()
+	x.build()
+	return x, nil
+}
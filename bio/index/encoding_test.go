@@ -0,0 +1,51 @@
+package index_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/sembio/go/bio/index"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestWriteTo(t *testing.T) {
+	texts := []string{
+		test.RandomStringFromRunes(test.Seed, 5000, []rune("ACGT")),
+		test.RandomStringFromRunes(test.Seed+1, 3000, []rune("ACGTN")),
+	}
+	x, _ := index.New(seqs(texts), index.SampleRateIs(16))
+	buf := new(bytes.Buffer)
+	n, err := x.WriteTo(buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("failed to write: %d %v", n, err)
+	}
+	back, err := index.ReadIndex(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{texts[0][100:110], texts[1][2000:2008], "ACG", "NN", "X"} {
+		if got, want := back.Locate(p), locate(texts, p); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q Want: %v, Got: %v", p, want, got)
+		}
+	}
+}
+
+func TestReadIndexErrors(t *testing.T) {
+	x, _ := index.New(seqs([]string{"GATTACA"}))
+	buf := new(bytes.Buffer)
+	x.WriteTo(buf)
+	good := buf.String()
+	for name, in := range map[string]string{
+		"Empty":     "",
+		"Magic":     "XXXX" + good[4:],
+		"Version":   good[:4] + "\x02" + good[5:],
+		"Truncated": good[:len(good)-1],
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := index.ReadIndex(bytes.NewBufferString(in)); err == nil {
+				t.Error("Want: error")
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package index
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/sembio/go/bio/sequence"
+)
+
+const (
+	// sentinel ends the indexed text
+	sentinel = 0
+
+	// separator joins the indexed sequences so no match spans two of them
+	separator = 1
+
+	// occRate is the number of BWT rows between counts of each symbol
+	occRate = 64
+)
+
+// Index is an FM-index of one or more sequences
+type Index struct {
+	starts   []int // where each sequence starts in the text
+	rate     int
+	alphabet []byte
+	codes    [256]byte
+	bwt      []byte
+	c        []int
+	occ      []int32
+	sampled  []uint64 // which BWT rows have their suffix array value sampled
+	ranks    []int32  // the number of sampled rows before each word of sampled
+	samples  []int32
+}
+
+// Hit is an exact match of a pattern in an indexed sequence
+type Hit struct {
+	Sequence int // which sequence the match is in, in the order indexed
+	Position int
+}
+
+// Option is a setting of an Index
+type Option func(*Index)
+
+// SampleRateIs samples the suffix array every n positions of the text (32 by default).
+// Higher rates make smaller indexes that are slower to locate matches with.
+func SampleRateIs(n int) Option {
+	return func(x *Index) {
+		x.rate = n
+	}
+}
+
+// New generates the Index of seqs. Letters are indexed as they are, so patterns must
+// match their case.
+func New(seqs []sequence.Interface, opts ...Option) (*Index, error) {
+	x := &Index{rate: 32}
+	for _, opt := range opts {
+		opt(x)
+	}
+	if x.rate < 1 {
+		return nil, fmt.Errorf("sample rate [%d] must be at least 1", x.rate)
+	}
+
+	texts := make([]string, len(seqs))
+	n := 0
+	for i, s := range seqs {
+		t, err := s.Range(0, s.Length())
+		if err != nil {
+			return nil, err
+		}
+		texts[i] = t
+		x.starts = append(x.starts, n)
+		n += len(t) + 1
+	}
+	if n >= 1<<31 {
+		return nil, fmt.Errorf("sequences too long to index: %d letters", n)
+	}
+	x.setAlphabet(texts)
+
+	// join the sequences, putting the sentinel in place of the last separator
+	s := make([]int32, 0, n+1)
+	for _, t := range texts {
+		for i := 0; i < len(t); i++ {
+			s = append(s, int32(x.codes[t[i]]))
+		}
+		s = append(s, separator)
+	}
+	if len(s) == 0 {
+		s = append(s, separator)
+	}
+	s[len(s)-1] = sentinel
+	sa := make([]int32, len(s))
+	sais(s, sa, len(x.alphabet)+2)
+
+	x.bwt = make([]byte, len(s))
+	x.sampled = make([]uint64, (len(s)+63)/64)
+	for i, p := range sa {
+		if p == 0 {
+			x.bwt[i] = byte(s[len(s)-1])
+		} else {
+			x.bwt[i] = byte(s[p-1])
+		}
+		if int(p)%x.rate == 0 {
+			x.sampled[i/64] |= 1 << uint(i%64)
+			x.samples = append(x.samples, p)
+		}
+	}
+	x.build()
+	return x, nil
+}
+
+// setAlphabet assigns codes to the letters of texts in order after the sentinel and
+// separator
+func (x *Index) setAlphabet(texts []string) {
+	var seen [256]bool
+	for _, t := range texts {
+		for i := 0; i < len(t); i++ {
+			seen[t[i]] = true
+		}
+	}
+	for b, ok := range seen {
+		if ok {
+			x.alphabet = append(x.alphabet, byte(b))
+		}
+	}
+	x.setCodes()
+}
+
+// setCodes assigns codes to the letters of the alphabet, leaving 0 for letters not in it
+func (x *Index) setCodes() {
+	for i, b := range x.alphabet {
+		x.codes[b] = byte(i + 2)
+	}
+}
+
+// build counts the symbols of the BWT and ranks its sampled rows
+func (x *Index) build() {
+	sigma := len(x.alphabet) + 2
+	x.c = make([]int, sigma+1)
+	counts := make([]int32, sigma)
+	x.occ = make([]int32, 0, (len(x.bwt)/occRate+1)*sigma)
+	for i, b := range x.bwt {
+		if i%occRate == 0 {
+			x.occ = append(x.occ, counts...)
+		}
+		counts[b]++
+	}
+	if len(x.bwt)%occRate == 0 {
+		x.occ = append(x.occ, counts...)
+	}
+	for a := 0; a < sigma; a++ {
+		x.c[a+1] = x.c[a] + int(counts[a])
+	}
+	x.ranks = make([]int32, len(x.sampled))
+	rank := int32(0)
+	for i, w := range x.sampled {
+		x.ranks[i] = rank
+		rank += int32(bits.OnesCount64(w))
+	}
+}
+
+// rank is the number of times symbol a occurs in the BWT before row i
+func (x *Index) rank(a byte, i int) int {
+	sigma := len(x.alphabet) + 2
+	block := i / occRate
+	n := int(x.occ[block*sigma+int(a)])
+	for _, b := range x.bwt[block*occRate : i] {
+		if b == a {
+			n++
+		}
+	}
+	return n
+}
+
+// lf is the row of the suffix starting one letter before the suffix of row i
+func (x *Index) lf(i int) int {
+	a := x.bwt[i]
+	return x.c[a] + x.rank(a, i)
+}
+
+// search is the range of BWT rows of the suffixes starting with pattern
+func (x *Index) search(pattern string) (int, int) {
+	if pattern == "" {
+		return 0, 0
+	}
+	lo, hi := 0, len(x.bwt)
+	for i := len(pattern) - 1; i >= 0 && lo < hi; i-- {
+		a := x.codes[pattern[i]]
+		if a == 0 {
+			return 0, 0
+		}
+		lo, hi = x.c[a]+x.rank(a, lo), x.c[a]+x.rank(a, hi)
+	}
+	return lo, hi
+}
+
+// Count is the number of matches of pattern in the indexed sequences
+func (x *Index) Count(pattern string) int {
+	lo, hi := x.search(pattern)
+	return hi - lo
+}
+
+// Locate finds every match of pattern in the indexed sequences, in order of sequence
+// and position
+func (x *Index) Locate(pattern string) []Hit {
+	lo, hi := x.search(pattern)
+	hits := make([]Hit, 0, hi-lo)
+	for row := lo; row < hi; row++ {
+		p := x.position(row)
+		i := sort.SearchInts(x.starts, p+1) - 1
+		hits = append(hits, Hit{i, p - x.starts[i]})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Sequence != hits[j].Sequence {
+			return hits[i].Sequence < hits[j].Sequence
+		}
+		return hits[i].Position < hits[j].Position
+	})
+	return hits
+}
+
+// position is the position in the text of the suffix of row i, walking back to the
+// nearest sampled suffix
+func (x *Index) position(i int) int {
+	steps := 0
+	for x.sampled[i/64]&(1<<uint(i%64)) == 0 {
+		i = x.lf(i)
+		steps++
+	}
+	w := i / 64
+	r := int(x.ranks[w]) + bits.OnesCount64(x.sampled[w]&(1<<uint(i%64)-1))
+	return int(x.samples[r]) + steps
+}
+
+// Sequences is the number of indexed sequences
+func (x *Index) Sequences() int {
+	return len(x.starts)
+}
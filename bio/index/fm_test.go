@@ -0,0 +1,112 @@
+package index_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/index"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// seqs are texts as sequences
+func seqs(texts []string) []sequence.Interface {
+	out := make([]sequence.Interface, len(texts))
+	for i, t := range texts {
+		out[i] = immutable.New(t)
+	}
+	return out
+}
+
+// locate finds every match of pattern in texts by scanning them
+func locate(texts []string, pattern string) []index.Hit {
+	hits := []index.Hit{}
+	for i, t := range texts {
+		for p := 0; pattern != "" && p+len(pattern) <= len(t); p++ {
+			if strings.HasPrefix(t[p:], pattern) {
+				hits = append(hits, index.Hit{Sequence: i, Position: p})
+			}
+		}
+	}
+	return hits
+}
+
+func TestLocate(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Locate finds every match and Count counts them",
+		prop.ForAll(
+			func(texts []string, pattern string, rate int) bool {
+				x, err := index.New(seqs(texts), index.SampleRateIs(rate))
+				if err != nil {
+					return false
+				}
+				want := locate(texts, pattern)
+				return reflect.DeepEqual(x.Locate(pattern), want) && x.Count(pattern) == len(want) &&
+					x.Sequences() == len(texts)
+			},
+			gen.SliceOfN(3, gen.RegexMatch("[ACGT]{0,200}")),
+			gen.RegexMatch("[ACGTN]{1,4}"),
+			gen.IntRange(1, 40),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestLocateEdges(t *testing.T) {
+	texts := []string{"GATTACA", "", "ACAGATT", "A"}
+	x, _ := index.New(seqs(texts))
+	for _, pattern := range []string{"", "A", "ACAG", "CAG", "GATTACA", "GATTACAA", "TTACAG", "acag", "N"} {
+		if got, want := x.Locate(pattern), locate(texts, pattern); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q Want: %v, Got: %v", pattern, want, got)
+		}
+	}
+	empty, err := index.New(nil)
+	if err != nil || empty.Count("A") != 0 || empty.Sequences() != 0 {
+		t.Errorf("Want: an empty index to match nothing, Got: %v", err)
+	}
+	if _, err := index.New(nil, index.SampleRateIs(0)); err == nil {
+		t.Error("Want: error for a sample rate of 0")
+	}
+}
+
+func TestCountLastBlock(t *testing.T) {
+	for _, n := range []uint{63, 127} {
+		texts := []string{test.RandomStringFromRunes(test.Seed, n, []rune("ACGT"))}
+		x, _ := index.New(seqs(texts))
+		for _, pattern := range []string{"A", "C", "G", "T", texts[0][n-3:]} {
+			if got, want := x.Count(pattern), len(locate(texts, pattern)); got != want {
+				t.Errorf("%d letters, %q Want: %d, Got: %d", n, pattern, want, got)
+			}
+		}
+	}
+}
+
+func ExampleIndex_Locate() {
+	x, _ := index.New([]sequence.Interface{
+		immutable.New("GATTACAGATTACA"),
+		immutable.New("TTACAT"),
+	})
+	fmt.Println(x.Count("TTACA"))
+	fmt.Println(x.Locate("TTACA"))
+	// Output:
+	// 3
+	// [{0 2} {0 9} {1 0}]
+}
+
+func BenchmarkLocate(b *testing.B) {
+	genome := test.RandomStringFromRunes(test.Seed, 1000000, []rune("ACGT"))
+	x, _ := index.New([]sequence.Interface{immutable.New(genome)})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := (i * 7919) % (len(genome) - 20)
+		x.Locate(genome[p : p+20])
+	}
+}
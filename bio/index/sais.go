@@ -0,0 +1,152 @@
+package index
+
+// SuffixArray sorts the suffixes of text, giving the starting position of each suffix
+// in lexicographic order
+func SuffixArray(text string) []int {
+	s := make([]int32, len(text)+1)
+	for i := 0; i < len(text); i++ {
+		s[i] = int32(text[i]) + 1
+	}
+	sa := make([]int32, len(s))
+	sais(s, sa, 257)
+	out := make([]int, len(text))
+	for i, p := range sa[1:] {
+		out[i] = int(p)
+	}
+	return out
+}
+
+// sais computes the suffix array of s with SA-IS (Nong, Zhang & Chan 2009). The last
+// symbol of s must be a unique 0 and all others must be below k.
+func sais(s, sa []int32, k int) {
+	n := len(s)
+	if n == 1 {
+		sa[0] = 0
+		return
+	}
+
+	// classify suffixes as S-type (smaller than the next) or L-type
+	stype := make([]bool, n)
+	stype[n-1] = true
+	for i := n - 2; i >= 0; i-- {
+		stype[i] = s[i] < s[i+1] || s[i] == s[i+1] && stype[i+1]
+	}
+	lms := func(i int) bool {
+		return i > 0 && stype[i] && !stype[i-1]
+	}
+	bkt := make([]int32, k)
+	buckets := func(ends bool) {
+		for i := range bkt {
+			bkt[i] = 0
+		}
+		for _, c := range s {
+			bkt[c]++
+		}
+		sum := int32(0)
+		for i, b := range bkt {
+			sum += b
+			if ends {
+				bkt[i] = sum
+			} else {
+				bkt[i] = sum - b
+			}
+		}
+	}
+	induce := func() {
+		buckets(false)
+		for i := 0; i < n; i++ {
+			if j := sa[i] - 1; sa[i] > 0 && !stype[j] {
+				sa[bkt[s[j]]] = j
+				bkt[s[j]]++
+			}
+		}
+		buckets(true)
+		for i := n - 1; i >= 0; i-- {
+			if j := sa[i] - 1; sa[i] > 0 && stype[j] {
+				bkt[s[j]]--
+				sa[bkt[s[j]]] = j
+			}
+		}
+	}
+
+	// sort the LMS substrings by inducing from their unsorted positions
+	for i := range sa {
+		sa[i] = -1
+	}
+	buckets(true)
+	for i := 1; i < n; i++ {
+		if lms(i) {
+			bkt[s[i]]--
+			sa[bkt[s[i]]] = int32(i)
+		}
+	}
+	induce()
+
+	// name the sorted LMS substrings, storing the reduced string at the end of sa
+	n1 := 0
+	for i := 0; i < n; i++ {
+		if lms(int(sa[i])) {
+			sa[n1] = sa[i]
+			n1++
+		}
+	}
+	for i := n1; i < n; i++ {
+		sa[i] = -1
+	}
+	name, prev := 0, -1
+	for i := 0; i < n1; i++ {
+		pos, diff := int(sa[i]), false
+		for d := 0; d < n; d++ {
+			if prev == -1 || s[pos+d] != s[prev+d] || stype[pos+d] != stype[prev+d] {
+				diff = true
+				break
+			}
+			if d > 0 && (lms(pos+d) || lms(prev+d)) {
+				break
+			}
+		}
+		if diff {
+			name++
+			prev = pos
+		}
+		sa[n1+pos/2] = int32(name - 1)
+	}
+	for i, j := n-1, n-1; i >= n1; i-- {
+		if sa[i] >= 0 {
+			sa[j] = sa[i]
+			j--
+		}
+	}
+
+	// sort the LMS suffixes, recursing unless every name is unique
+	s1, sa1 := sa[n-n1:], sa[:n1]
+	if name < n1 {
+		sais(s1, sa1, name)
+	} else {
+		for i, c := range s1 {
+			sa1[c] = int32(i)
+		}
+	}
+
+	// induce the suffix array from the sorted LMS suffixes
+	for i, j := 1, 0; i < n; i++ {
+		if lms(i) {
+			s1[j] = int32(i)
+			j++
+		}
+	}
+	for i := range sa1 {
+		sa1[i] = s1[sa1[i]]
+	}
+	for i := n1; i < n; i++ {
+		sa[i] = -1
+	}
+	buckets(true)
+	for i := n1 - 1; i >= 0; i-- {
+		j := sa[i]
+		sa[i] = -1
+		bkt[s[j]]--
+		sa[bkt[s[j]]] = j
+	}
+	induce()
+}
@@ -0,0 +1,56 @@
+package index_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/index"
+	"github.com/sembio/go/bio/test"
+)
+
+// naive sorts the suffixes of text by comparing them
+func naive(text string) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	sort.Slice(sa, func(i, j int) bool { return text[sa[i]:] < text[sa[j]:] })
+	return sa
+}
+
+func TestSuffixArray(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("SuffixArray sorts suffixes",
+		prop.ForAll(
+			func(text string) bool {
+				return reflect.DeepEqual(index.SuffixArray(text), naive(text))
+			},
+			gen.OneGenOf(
+				gen.RegexMatch("[AC]{0,300}"),
+				gen.RegexMatch("[ACGT]{0,300}"),
+				gen.AnyString(),
+			),
+		),
+	)
+	properties.TestingRun(t)
+
+	for _, text := range []string{"", "A", "AAAAAAAAAA", "ACACACACAC", "mississippi", "GATTACAGATTACA"} {
+		if got, want := index.SuffixArray(text), naive(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q Want: %v, Got: %v", text, want, got)
+		}
+	}
+}
+
+func BenchmarkSuffixArray(b *testing.B) {
+	text := test.RandomStringFromRunes(test.Seed, 1000000, []rune("ACGT"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.SuffixArray(text)
+	}
+}
@@ -0,0 +1,299 @@
+package demux
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/io/fastq/header"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Undetermined is the name of the bucket of reads that match no sample
+const Undetermined = "Undetermined"
+
+// Demuxer assigns reads to samples by their barcodes
+type Demuxer struct {
+	samples    []Sample
+	f          sequence.Generator
+	mismatches uint
+	inline     bool
+	singleEnd  error // why single-end reads cannot be assigned, if they cannot
+
+	mu     sync.Mutex
+	counts map[string]uint
+}
+
+// Option is a setting of a Demuxer
+type Option func(*Demuxer)
+
+// MismatchesIs sets the most mismatches allowed between a barcode of a read
+// and of a sample (1 by default)
+func MismatchesIs(n uint) Option {
+	return func(d *Demuxer) {
+		d.mismatches = n
+	}
+}
+
+// Inline reads barcodes from the start of the reads (the i7 from read 1 and the
+// i5 from read 2) rather than their headers, trimming them from assigned reads
+func Inline() Option {
+	return func(d *Demuxer) {
+		d.inline = true
+	}
+}
+
+// New generates a Demuxer for the samples using the generator f to create trimmed
+// sequences. It returns an error if samples share a name, have invalid barcodes,
+// or have barcodes so alike that a read could match more than one of them. Samples
+// told apart only by their i5 barcodes cannot be assigned single-end inline reads,
+// which carry no i5, so Assign returns an error for them instead.
+func New(samples []Sample, f sequence.Generator, opts ...Option) (*Demuxer, error) {
+	d := &Demuxer{
+		samples:    samples,
+		f:          f,
+		mismatches: 1,
+		counts:     map[string]uint{Undetermined: 0},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	for _, s := range samples {
+		if s.Name == "" || s.Name == Undetermined {
+			return nil, fmt.Errorf("invalid sample name: %q", s.Name)
+		}
+		if _, ok := d.counts[s.Name]; ok {
+			return nil, fmt.Errorf("sample %q is listed more than once", s.Name)
+		}
+		if s.I7 == "" {
+			return nil, fmt.Errorf("sample %q has no i7 barcode", s.Name)
+		}
+		for _, b := range []string{s.I7, s.I5} {
+			if _, err := immutable.NewDna(b); err != nil {
+				return nil, fmt.Errorf("sample %q: %v", s.Name, err)
+			}
+		}
+		d.counts[s.Name] = 0
+	}
+	for i, a := range samples {
+		for _, b := range samples[i+1:] {
+			if d.alike(a, b, true) {
+				return nil, fmt.Errorf("barcodes of samples %q and %q are too alike to allow %d mismatches",
+					a.Name, b.Name, d.mismatches)
+			}
+			if d.inline && d.singleEnd == nil && d.alike(a, b, false) {
+				d.singleEnd = fmt.Errorf("i7 barcodes of samples %q and %q are too alike to allow %d mismatches "+
+					"in single-end reads", a.Name, b.Name, d.mismatches)
+			}
+		}
+	}
+	return d, nil
+}
+
+// alike is whether a read could match both samples a and b, comparing i5 barcodes
+// only if dual
+func (d *Demuxer) alike(a, b Sample, dual bool) bool {
+	return hamming(a.I7, b.I7) <= 2*d.mismatches && (!dual || hamming(a.I5, b.I5) <= 2*d.mismatches)
+}
+
+// hamming is the number of mismatches between barcode b and the start of
+// observed o, with bases beyond the end of o counted as mismatches
+func hamming(b, o string) uint {
+	n := uint(0)
+	for i := 0; i < len(b); i++ {
+		if i >= len(o) || o[i] != b[i] || o[i] == 'N' {
+			n++
+		}
+	}
+	return n
+}
+
+// match finds the sample whose barcodes are within the allowed mismatches
+// of the observed barcodes, ignoring i5 barcodes unless dual
+func (d *Demuxer) match(i7, i5 string, dual bool) (Sample, bool) {
+	for _, s := range d.samples {
+		if hamming(s.I7, i7) <= d.mismatches && (!dual || hamming(s.I5, i5) <= d.mismatches) {
+			return s, true
+		}
+	}
+	return Sample{}, false
+}
+
+// barcodes reads the observed barcodes from the header of a read
+func barcodes(r fastq.Interface) (string, string, error) {
+	h, err := header.Parse(r.Header())
+	if err != nil {
+		return "", "", err
+	}
+	indexes := h.Indexes()
+	switch len(indexes) {
+	case 0:
+		return "", "", fmt.Errorf("header of %q has no index", r.Header())
+	case 1:
+		return strings.ToUpper(indexes[0]), "", nil
+	default:
+		return strings.ToUpper(indexes[0]), strings.ToUpper(indexes[1]), nil
+	}
+}
+
+// Assign finds the sample of a single-end read, counting it.
+// Reads matching no sample are assigned to Undetermined. With inline barcodes
+// only the i7 barcode is matched and the read is returned without it,
+// otherwise the read is returned as is.
+func (d *Demuxer) Assign(r fastq.Interface) (string, fastq.Interface, error) {
+	var i7, i5 string
+	var err error
+	if d.inline && d.singleEnd != nil {
+		return "", nil, d.singleEnd
+	}
+	if d.inline {
+		i7 = strings.ToUpper(r.Sequence())
+	} else if i7, i5, err = barcodes(r); err != nil {
+		return "", nil, err
+	}
+	s, ok := d.match(i7, i5, !d.inline)
+	if !ok {
+		d.count(Undetermined)
+		return Undetermined, r, nil
+	}
+	if d.inline {
+		if r, err = d.trim(r, len(s.I7)); err != nil {
+			return "", nil, err
+		}
+	}
+	d.count(s.Name)
+	return s.Name, r, nil
+}
+
+// AssignPair finds the sample of a read pair, counting it, as Assign does for single-end reads
+func (d *Demuxer) AssignPair(r1, r2 fastq.Interface) (string, fastq.Interface, fastq.Interface, error) {
+	var i7, i5 string
+	var err error
+	if d.inline {
+		i7, i5 = strings.ToUpper(r1.Sequence()), strings.ToUpper(r2.Sequence())
+	} else if i7, i5, err = barcodes(r1); err != nil {
+		return "", nil, nil, err
+	}
+	s, ok := d.match(i7, i5, true)
+	if !ok {
+		d.count(Undetermined)
+		return Undetermined, r1, r2, nil
+	}
+	if d.inline {
+		if r1, err = d.trim(r1, len(s.I7)); err != nil {
+			return "", nil, nil, err
+		}
+		if r2, err = d.trim(r2, len(s.I5)); err != nil {
+			return "", nil, nil, err
+		}
+	}
+	d.count(s.Name)
+	return s.Name, r1, r2, nil
+}
+
+// trim removes the first n bases of a read
+func (d *Demuxer) trim(r fastq.Interface, n int) (fastq.Interface, error) {
+	if n == 0 {
+		return r, nil
+	}
+	seq, qual := r.Sequence(), r.Quality()
+	if n > len(seq) || n > len(qual) {
+		return nil, fmt.Errorf("read %q is shorter than its barcode", r.Header())
+	}
+	seqx, err := d.f(seq[n:])
+	if err != nil {
+		return nil, err
+	}
+	return fastq.New(r.Header(), qual[n:], seqx), nil
+}
+
+// count adds a read to the count of sample
+func (d *Demuxer) count(sample string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.counts[sample]++
+}
+
+// Counts are the number of reads (or read pairs) assigned to each sample and to Undetermined
+func (d *Demuxer) Counts() map[string]uint {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	counts := make(map[string]uint, len(d.counts))
+	for k, v := range d.counts {
+		counts[k] = v
+	}
+	return counts
+}
+
+// Report writes a tab-separated table of the reads assigned to each sample in the
+// order of the sample sheet, then Undetermined, with the fraction of all reads
+func (d *Demuxer) Report(w io.Writer) error {
+	counts := d.Counts()
+	total := uint(0)
+	for _, c := range counts {
+		total += c
+	}
+	names := append(d.Names(), Undetermined)
+
+	out := new(strings.Builder)
+	out.WriteString("sample\treads\tfraction\n")
+	for _, name := range names {
+		fraction := 0.0
+		if total != 0 {
+			fraction = float64(counts[name]) / float64(total)
+		}
+		fmt.Fprintf(out, "%s\t%d\t%.4f\n", name, counts[name], fraction)
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// Demultiplex writes each single-end read from s to the writer of its sample,
+// stopping at the first error
+func (d *Demuxer) Demultiplex(s *fastq.Scanner, writer func(sample string) (io.Writer, error)) error {
+	for s.Scan() {
+		sample, r, err := d.Assign(s.Record())
+		if err != nil {
+			return err
+		}
+		w, err := writer(sample)
+		if err != nil {
+			return err
+		}
+		if _, err := fastq.WriteSingle(w, r, d.f); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// DemultiplexPairs writes each read pair from s to the writer of its sample,
+// stopping at the first error
+func (d *Demuxer) DemultiplexPairs(s *fastq.PairScanner, writer func(sample string) (*fastq.PairWriter, error)) error {
+	for s.Scan() {
+		sample, r1, r2, err := d.AssignPair(s.Pair())
+		if err != nil {
+			return err
+		}
+		w, err := writer(sample)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(r1, r2); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// Names are the names of the samples in the order of the sample sheet
+func (d *Demuxer) Names() []string {
+	names := make([]string, len(d.samples))
+	for i, s := range d.samples {
+		names[i] = s.Name
+	}
+	return names
+}
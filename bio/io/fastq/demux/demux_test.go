@@ -0,0 +1,184 @@
+package demux_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/io/fastq/demux"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+func dna(s string) (sequence.Interface, error) {
+	return immutable.NewDna(s)
+}
+
+func record(name, index, seq string) fastq.Interface {
+	return fastq.New(name+" 1:N:0:"+index, strings.Repeat("I", len(seq)), immutable.New(seq))
+}
+
+func TestNew(t *testing.T) {
+	tt := []struct {
+		name    string
+		samples []demux.Sample
+		opts    []demux.Option
+		fails   bool
+	}{
+		{"Distinct", []demux.Sample{{"A", "AAAAAA", ""}, {"B", "CCCCCC", ""}}, nil, false},
+		{"Collision", []demux.Sample{{"A", "AAAAAA", ""}, {"B", "AAAACC", ""}}, nil, true},
+		{"No collision without mismatches", []demux.Sample{{"A", "AAAAAA", ""}, {"B", "AAAACC", ""}},
+			[]demux.Option{demux.MismatchesIs(0)}, false},
+		{"Dual indexes tell apart", []demux.Sample{{"A", "AAAAAA", "GGGGGG"}, {"B", "AAAAAA", "TTTTTT"}}, nil, false},
+		{"Duplicate name", []demux.Sample{{"A", "AAAAAA", ""}, {"A", "CCCCCC", ""}}, nil, true},
+		{"Reserved name", []demux.Sample{{demux.Undetermined, "AAAAAA", ""}}, nil, true},
+		{"No barcode", []demux.Sample{{"A", "", ""}}, nil, true},
+		{"Invalid barcode", []demux.Sample{{"A", "AAAXAA", ""}}, nil, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := demux.New(tc.samples, dna, tc.opts...); (err != nil) != tc.fails {
+				t.Errorf("Want error: %v, Got: %v", tc.fails, err)
+			}
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	d, err := demux.New([]demux.Sample{
+		{"A", "ACGTAC", "GGTTAA"},
+		{"B", "TGCATG", "CCAATT"},
+	}, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := []struct {
+		index string
+		want  string
+	}{
+		{"ACGTAC+GGTTAA", "A"},
+		{"ACGTAA+GGTTAA", "A"},
+		{"ACGTAA+GGTTCA", "A"},
+		{"ACGTNC+GGTTAA", "A"},
+		{"TGCATG+CCAATT", "B"},
+		{"ACGAAA+GGTTAA", demux.Undetermined},
+		{"ACGTAC+CCAATT", demux.Undetermined},
+		{"ACGTAC", demux.Undetermined},
+	}
+	for _, tc := range tt {
+		t.Run(tc.index, func(t *testing.T) {
+			got, r, err := d.Assign(record("M:1:FC:1:1:1:1", tc.index, "ACGT"))
+			if err != nil || got != tc.want || r.Sequence() != "ACGT" {
+				t.Errorf("Want: %s, Got: %s %v", tc.want, got, err)
+			}
+		})
+	}
+	want := map[string]uint{"A": 4, "B": 1, demux.Undetermined: 3}
+	if got := d.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if _, _, err := d.Assign(fastq.New("no index", "I", immutable.New("A"))); err == nil {
+		t.Error("reads without an index in their header should error")
+	}
+}
+
+func TestAssignInline(t *testing.T) {
+	d, err := demux.New([]demux.Sample{{"A", "ACGT", "TTTT"}, {"B", "GGCC", "CCAA"}}, dna, demux.Inline())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample, r, err := d.Assign(fastq.New("r", "ABCDEFGH", immutable.New("GGCCTTAA")))
+	if err != nil || sample != "B" || r.Sequence() != "TTAA" || r.Quality() != "EFGH" {
+		t.Errorf("Want: B TTAA EFGH, Got: %s %v %v", sample, r, err)
+	}
+	sample, r1, r2, err := d.AssignPair(
+		fastq.New("r/1", "IIIIII", immutable.New("ACGTGG")),
+		fastq.New("r/2", "IIIIII", immutable.New("TTTTCA")),
+	)
+	if err != nil || sample != "A" || r1.Sequence() != "GG" || r2.Sequence() != "CA" {
+		t.Errorf("Want: A GG CA, Got: %s %v %v %v", sample, r1, r2, err)
+	}
+	sample, r1, _, err = d.AssignPair(
+		fastq.New("r/1", "IIIIII", immutable.New("ACGTGG")),
+		fastq.New("r/2", "IIIIII", immutable.New("CCAACA")),
+	)
+	if err != nil || sample != demux.Undetermined || r1.Sequence() != "ACGTGG" {
+		t.Errorf("Want: untrimmed Undetermined, Got: %s %v %v", sample, r1, err)
+	}
+}
+
+func TestAssignInlineSharedI7(t *testing.T) {
+	d, err := demux.New([]demux.Sample{{"A", "ACGTACGT", "AAAAAAAA"}, {"B", "ACGTACGT", "CCCCCCCC"}}, dna, demux.Inline())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample, _, err := d.Assign(fastq.New("r", "IIIIIIIIII", immutable.New("ACGTACGTGG"))); err == nil {
+		t.Errorf("Want: error telling samples apart only by i5, Got: %s", sample)
+	}
+	sample, _, _, err := d.AssignPair(
+		fastq.New("r/1", "IIIIIIIIII", immutable.New("ACGTACGTGG")),
+		fastq.New("r/2", "IIIIIIIIII", immutable.New("CCCCCCCCGG")),
+	)
+	if err != nil || sample != "B" {
+		t.Errorf("Want: B, Got: %s %v", sample, err)
+	}
+}
+
+func TestDemultiplexPairs(t *testing.T) {
+	d, err := demux.New([]demux.Sample{{"A", "ACGTAC", ""}, {"B", "TGCATG", ""}}, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := "@M:1:FC:1:1:1:1 1:N:0:ACGTAC\nAC\n+\nII\n@M:1:FC:1:1:1:1 2:N:0:ACGTAC\nGT\n+\nII\n" +
+		"@M:1:FC:1:1:1:2 1:N:0:TGCATG\nAA\n+\nII\n@M:1:FC:1:1:1:2 2:N:0:TGCATG\nTT\n+\nII\n" +
+		"@M:1:FC:1:1:1:3 1:N:0:GGGGGG\nCC\n+\nII\n@M:1:FC:1:1:1:3 2:N:0:GGGGGG\nGG\n+\nII\n"
+	outs := make(map[string]*bytes.Buffer)
+	err = d.DemultiplexPairs(fastq.NewInterleavedScanner(strings.NewReader(in), dna),
+		func(sample string) (*fastq.PairWriter, error) {
+			if _, ok := outs[sample]; !ok {
+				outs[sample] = new(bytes.Buffer)
+			}
+			return fastq.NewInterleavedWriter(outs[sample]), nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"A":                "@M:1:FC:1:1:1:1 1:N:0:ACGTAC\nAC\n+\nII\n@M:1:FC:1:1:1:1 2:N:0:ACGTAC\nGT\n+\nII\n",
+		"B":                "@M:1:FC:1:1:1:2 1:N:0:TGCATG\nAA\n+\nII\n@M:1:FC:1:1:1:2 2:N:0:TGCATG\nTT\n+\nII\n",
+		demux.Undetermined: "@M:1:FC:1:1:1:3 1:N:0:GGGGGG\nCC\n+\nII\n@M:1:FC:1:1:1:3 2:N:0:GGGGGG\nGG\n+\nII\n",
+	}
+	for sample, w := range want {
+		if outs[sample] == nil || outs[sample].String() != w {
+			t.Errorf("Want for %s:\n%s\nGot:\n%v", sample, w, outs[sample])
+		}
+	}
+}
+
+func ExampleDemuxer_Demultiplex() {
+	sheet := "Sample_ID,index\nA,ACGTAC\nB,TGCATG\n"
+	samples, _ := demux.ReadSampleSheet(strings.NewReader(sheet))
+	d, _ := demux.New(samples, dna)
+
+	in := "@M:1:FC:1:1:1:1 1:N:0:ACGTAC\nAC\n+\nII\n@M:1:FC:1:1:1:2 1:N:0:TGCATT\nGT\n+\nII\n@M:1:FC:1:1:1:3 1:N:0:NNNNNN\nGG\n+\nII\n"
+	err := d.Demultiplex(fastq.NewScanner(strings.NewReader(in), dna),
+		func(sample string) (io.Writer, error) {
+			return ioutil.Discard, nil
+		},
+	)
+	fmt.Println(err)
+	d.Report(os.Stdout)
+	// Output:
+	// <nil>
+	// sample	reads	fraction
+	// A	1	0.3333
+	// B	1	0.3333
+	// Undetermined	1	0.3333
+}
@@ -0,0 +1,8 @@
+/*
+Package demux splits pooled FASTQ reads into samples by their index barcodes.
+
+Barcodes are read from Casava 1.8 headers (see package header) or, for inline
+barcodes, from the start of the reads themselves. Reads matching no sample go to
+the Undetermined bucket.
+*/
+package demux
@@ -0,0 +1,74 @@
+package demux
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Sample is a sample of a pool and its i7 (and optional i5) barcodes
+type Sample struct {
+	Name string
+	I7   string
+	I5   string
+}
+
+// ReadSampleSheet reads samples from a CSV sample sheet. The columns are named by
+// the first row (of the [Data] section of an Illumina sample sheet if there is one)
+// with Sample_ID, index, and (for dual indexes) index2 columns read.
+func ReadSampleSheet(r io.Reader) ([]Sample, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		if len(row) > 0 && strings.EqualFold(strings.TrimSpace(row[0]), "[Data]") {
+			rows = rows[i+1:]
+			break
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("sample sheet has no header row")
+	}
+
+	name, i7, i5 := -1, -1, -1
+	for i, col := range rows[0] {
+		switch strings.ToLower(strings.TrimSpace(col)) {
+		case "sample_id":
+			name = i
+		case "index":
+			i7 = i
+		case "index2":
+			i5 = i
+		}
+	}
+	if name < 0 || i7 < 0 {
+		return nil, fmt.Errorf("sample sheet needs Sample_ID and index columns")
+	}
+
+	samples := make([]Sample, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		if len(row) == 0 || (len(row) == 1 && strings.TrimSpace(row[0]) == "") {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(row[0]), "[") {
+			break
+		}
+		if len(row) <= name || len(row) <= i7 {
+			return samples, fmt.Errorf("sample sheet row %d is missing columns", i+2)
+		}
+		s := Sample{
+			Name: strings.TrimSpace(row[name]),
+			I7:   strings.ToUpper(strings.TrimSpace(row[i7])),
+		}
+		if i5 >= 0 && len(row) > i5 {
+			s.I5 = strings.ToUpper(strings.TrimSpace(row[i5]))
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
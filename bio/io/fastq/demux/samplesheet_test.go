@@ -0,0 +1,38 @@
+package demux_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/io/fastq/demux"
+)
+
+func TestReadSampleSheet(t *testing.T) {
+	tt := []struct {
+		name  string
+		sheet string
+		want  []demux.Sample
+		fails bool
+	}{
+		{"Plain CSV", "Sample_ID,index\nA,ACGTAC\nB,tgcatg\n",
+			[]demux.Sample{{Name: "A", I7: "ACGTAC"}, {Name: "B", I7: "TGCATG"}}, false},
+		{"Illumina sheet", "[Header]\nIEMFileVersion,4\n\n[Reads]\n151\n\n[Data]\nLane,Sample_ID,Sample_Name,index,index2\n1,A,a,ACGTAC,GGTTAA\n1,B,b,TGCATG,CCAATT\n",
+			[]demux.Sample{{Name: "A", I7: "ACGTAC", I5: "GGTTAA"}, {Name: "B", I7: "TGCATG", I5: "CCAATT"}}, false},
+		{"Section after data", "[Data]\nSample_ID,index\nA,ACGTAC\n[Settings]\nAdapter,AGATCGGAAGAGC\n",
+			[]demux.Sample{{Name: "A", I7: "ACGTAC"}}, false},
+		{"Missing index column", "Sample_ID,Sample_Name\nA,a\n", nil, true},
+		{"Empty", "", nil, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := demux.ReadSampleSheet(strings.NewReader(tc.sheet))
+			if (err != nil) != tc.fails {
+				t.Fatalf("Want error: %v, Got: %v", tc.fails, err)
+			}
+			if !tc.fails && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Want: %v, Got: %v", tc.want, got)
+			}
+		})
+	}
+}
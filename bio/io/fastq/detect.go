@@ -0,0 +1,58 @@
+package fastq
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// DetectEncoding samples the qualities of the first n records (all records if n is 0)
+// of a FASTQ file and reports the most likely quality.Encoding along with every
+// quality.Encoding whose range of characters holds all the qualities seen.
+// When more than one is possible, the most likely is the first of:
+// Illumina18, Sanger, Illumina15, Illumina13, Solexa.
+func DetectEncoding(r io.Reader, n uint) (quality.Encoding, []quality.Encoding, error) {
+	s := NewScanner(r, func(s string) (sequence.Interface, error) {
+		return immutable.New(s), nil
+	})
+	min, max := byte(0xFF), byte(0)
+	count := uint(0)
+	for (n == 0 || count < n) && s.Scan() {
+		q := s.Record().Quality()
+		for i := 0; i < len(q); i++ {
+			if q[i] < min {
+				min = q[i]
+			}
+			if q[i] > max {
+				max = q[i]
+			}
+		}
+		count++
+	}
+	if err := s.Err(); err != nil {
+		return 0, nil, err
+	}
+	if min > max {
+		return 0, nil, fmt.Errorf("no qualities found to detect encoding")
+	}
+
+	possible := make([]quality.Encoding, 0)
+	for _, e := range []quality.Encoding{
+		quality.Illumina18,
+		quality.Sanger,
+		quality.Illumina15,
+		quality.Illumina13,
+		quality.Solexa,
+	} {
+		if e.Min() <= min && max <= e.Max() {
+			possible = append(possible, e)
+		}
+	}
+	if len(possible) == 0 {
+		return 0, possible, fmt.Errorf("qualities from %q to %q fit no known encoding", min, max)
+	}
+	return possible[0], possible, nil
+}
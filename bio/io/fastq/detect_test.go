@@ -0,0 +1,84 @@
+package fastq_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	tt := []struct {
+		name     string
+		quals    []string
+		want     quality.Encoding
+		possible []quality.Encoding
+	}{
+		{"Low Phred+33", []string{"!!5I", "+5?"}, quality.Illumina18,
+			[]quality.Encoding{quality.Illumina18, quality.Sanger}},
+		{"Illumina 1.8 maximum", []string{"!!5J"}, quality.Illumina18,
+			[]quality.Encoding{quality.Illumina18}},
+		{"Solexa negatives", []string{";;Th", "hhhh"}, quality.Solexa,
+			[]quality.Encoding{quality.Solexa}},
+		{"Illumina 1.3 zero", []string{"@@Th"}, quality.Illumina13,
+			[]quality.Encoding{quality.Illumina13, quality.Solexa}},
+		{"Illumina 1.5 maximum", []string{"BBTi"}, quality.Illumina15,
+			[]quality.Encoding{quality.Illumina15}},
+		{"High scores only", []string{"JJJ", "III"}, quality.Illumina18,
+			[]quality.Encoding{quality.Illumina18, quality.Illumina15, quality.Illumina13, quality.Solexa}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			in := new(strings.Builder)
+			for i, q := range tc.quals {
+				fmt.Fprintf(in, "@r%d\n%s\n+\n%s\n", i, strings.Repeat("A", len(q)), q)
+			}
+			got, possible, err := fastq.DetectEncoding(strings.NewReader(in.String()), 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want || !reflect.DeepEqual(possible, tc.possible) {
+				t.Errorf("Want: %s %v, Got: %s %v", tc.want, tc.possible, got, possible)
+			}
+		})
+	}
+	t.Run("Samples only the first records", func(t *testing.T) {
+		in := "@r1\nAA\n+\nhh\n@r2\nAA\n+\n!!\n"
+		got, _, err := fastq.DetectEncoding(strings.NewReader(in), 1)
+		if err != nil || got != quality.Illumina15 {
+			t.Errorf("Want: %s, Got: %s %v", quality.Illumina15, got, err)
+		}
+	})
+	t.Run("No known encoding errors", func(t *testing.T) {
+		if _, _, err := fastq.DetectEncoding(strings.NewReader("@r1\nAA\n+\n!~\n"), 0); err == nil {
+			t.Error("qualities spanning '!' to '~' should error")
+		}
+	})
+	t.Run("Empty input errors", func(t *testing.T) {
+		if _, _, err := fastq.DetectEncoding(strings.NewReader(""), 0); err == nil {
+			t.Error("empty input should error")
+		}
+	})
+	t.Run("Generated FASTQ is Phred+33", func(t *testing.T) {
+		r := fastq.TestGenMultiFastq(test.Seed, 500, 10, hashmap.NewDna())
+		got, _, err := fastq.DetectEncoding(bytes.NewReader(r), 0)
+		if err != nil || (got != quality.Illumina18 && got != quality.Sanger) {
+			t.Errorf("Want: Phred+33, Got: %s %v", got, err)
+		}
+	})
+}
+
+func ExampleDetectEncoding() {
+	in := "@r1\nACGT\n+\n;@Th\n"
+	e, possible, err := fastq.DetectEncoding(strings.NewReader(in), 100)
+
+	fmt.Println(e, possible, err)
+	// Output:
+	// Solexa+64 [Solexa+64] <nil>
+}
@@ -0,0 +1,4 @@
+/*
+Package header parses the fields of Illumina and SRA FASTQ header lines
+*/
+package header
@@ -0,0 +1,251 @@
+package header
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format is a style of FASTQ header line
+type Format int
+
+const (
+	// Unknown is any header not in a recognized format
+	Unknown Format = iota
+
+	// Casava18 is the Illumina Casava 1.8+ format, for example
+	// EAS139:136:FC706VJ:2:2104:15343:197393 1:Y:18:ATCACG
+	Casava18
+
+	// Illumina is the older Illumina format, for example
+	// HWUSI-EAS100R:6:73:941:1973#0/1
+	Illumina
+
+	// SRA is the format of reads from the Sequence Read Archive, for example
+	// SRR001666.1 071112_SLXA-EAS1_s_7:5:1:817:345 length=36
+	SRA
+)
+
+// String provides a human-readable name of the Format
+func (f Format) String() string {
+	switch f {
+	case Casava18:
+		return "Casava 1.8"
+	case Illumina:
+		return "Illumina"
+	case SRA:
+		return "SRA"
+	default:
+		return "Unknown"
+	}
+}
+
+// Header holds the fields of a FASTQ header line.
+// Fields not given by the Format of the header are left as their zero values.
+type Header struct {
+	Format Format
+
+	Instrument string
+	Run        int
+	Flowcell   string
+	Lane       int
+	Tile       int
+	X          int
+	Y          int
+	UMI        string
+
+	// Read is which read of a pair or set (1 or 2 for pairs, 0 if not given)
+	Read int
+
+	// Filtered is whether the read failed the instrument filter
+	Filtered bool
+
+	// Control is 0 unless the read is a control, when its bits say which
+	Control int
+
+	// Index is the index sequence (or number for some Illumina headers) with any
+	// dual indexes joined by '+'
+	Index string
+
+	Accession string
+	Spot      int
+	Length    int
+
+	// Original is the header of an SRA read as first submitted
+	Original string
+}
+
+// Indexes splits dual indexes
+func (h Header) Indexes() []string {
+	if h.Index == "" {
+		return []string{}
+	}
+	return strings.Split(h.Index, "+")
+}
+
+// Parse reads the fields of a FASTQ header line (with or without its leading '@'),
+// trying the Casava 1.8, older Illumina, then SRA formats
+func Parse(line string) (Header, error) {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "@")
+	if h, err := casava18(line); err == nil {
+		return h, nil
+	}
+	if h, err := illumina(line); err == nil {
+		return h, nil
+	}
+	if h, err := sra(line); err == nil {
+		return h, nil
+	}
+	return Header{}, fmt.Errorf("header %q is not in a recognized format", line)
+}
+
+// casava18 parses a Casava 1.8 header
+func casava18(line string) (Header, error) {
+	h := Header{Format: Casava18}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return h, fmt.Errorf("missing Casava 1.8 comment")
+	}
+	id := strings.Split(fields[0], ":")
+	if len(id) != 7 && len(id) != 8 {
+		return h, fmt.Errorf("expected 7 or 8 fields in Casava 1.8 name, got %d", len(id))
+	}
+	h.Instrument, h.Flowcell = id[0], id[2]
+	if err := atois(id[1:2], &h.Run); err != nil {
+		return h, err
+	}
+	if err := atois(id[3:7], &h.Lane, &h.Tile, &h.X, &h.Y); err != nil {
+		return h, err
+	}
+	if len(id) == 8 {
+		h.UMI = id[7]
+	}
+
+	comment := strings.Split(fields[1], ":")
+	if len(comment) != 4 {
+		return h, fmt.Errorf("expected 4 fields in Casava 1.8 comment, got %d", len(comment))
+	}
+	if err := atois(comment[:1], &h.Read); err != nil {
+		return h, err
+	}
+	switch comment[1] {
+	case "Y":
+		h.Filtered = true
+	case "N":
+	default:
+		return h, fmt.Errorf("filter flag %q is neither Y nor N", comment[1])
+	}
+	if err := atois(comment[2:3], &h.Control); err != nil {
+		return h, err
+	}
+	h.Index = comment[3]
+	return h, nil
+}
+
+// illumina parses an older Illumina header
+func illumina(line string) (Header, error) {
+	h := Header{Format: Illumina}
+	name := strings.Fields(line)
+	if len(name) == 0 {
+		return h, fmt.Errorf("empty header")
+	}
+	id := name[0]
+	if n := len(id); n > 2 && id[n-2] == '/' {
+		if err := atois([]string{id[n-1:]}, &h.Read); err != nil {
+			return h, err
+		}
+		id = id[:n-2]
+	}
+	if i := strings.LastIndexByte(id, '#'); i >= 0 {
+		id, h.Index = id[:i], id[i+1:]
+	}
+	fields := strings.Split(id, ":")
+	if len(fields) != 5 {
+		return h, fmt.Errorf("expected 5 fields in Illumina name, got %d", len(fields))
+	}
+	h.Instrument = fields[0]
+	if err := atois(fields[1:], &h.Lane, &h.Tile, &h.X, &h.Y); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// sra parses an SRA header, along with the original header where it is recognized
+func sra(line string) (Header, error) {
+	h := Header{Format: SRA}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return h, fmt.Errorf("empty header")
+	}
+	id := fields[0]
+	if n := len(id); n > 2 && id[n-2] == '/' {
+		if err := atois([]string{id[n-1:]}, &h.Read); err != nil {
+			return h, err
+		}
+		id = id[:n-2]
+	}
+	parts := strings.Split(id, ".")
+	if len(parts) < 2 || len(parts) > 3 || !accession(parts[0]) {
+		return h, fmt.Errorf("%q is not an SRA read name", id)
+	}
+	h.Accession = parts[0]
+	if err := atois(parts[1:2], &h.Spot); err != nil {
+		return h, err
+	}
+	if len(parts) == 3 {
+		if err := atois(parts[2:], &h.Read); err != nil {
+			return h, err
+		}
+	}
+
+	rest := fields[1:]
+	if n := len(rest); n > 0 && strings.HasPrefix(rest[n-1], "length=") {
+		if err := atois([]string{strings.TrimPrefix(rest[n-1], "length=")}, &h.Length); err != nil {
+			return h, err
+		}
+		rest = rest[:n-1]
+	}
+	h.Original = strings.Join(rest, " ")
+	if h.Original == "" {
+		return h, nil
+	}
+	original, err := casava18(h.Original)
+	if err != nil {
+		original, err = illumina(h.Original)
+	}
+	if err != nil {
+		return h, nil
+	}
+	h.Instrument, h.Run, h.Flowcell = original.Instrument, original.Run, original.Flowcell
+	h.Lane, h.Tile, h.X, h.Y, h.UMI = original.Lane, original.Tile, original.X, original.Y, original.UMI
+	h.Filtered, h.Control, h.Index = original.Filtered, original.Control, original.Index
+	if h.Read == 0 {
+		h.Read = original.Read
+	}
+	return h, nil
+}
+
+// accession reports whether s is a run accession such as SRR001666 (or ERR, DRR)
+func accession(s string) bool {
+	if len(s) < 4 || (s[0] != 'S' && s[0] != 'E' && s[0] != 'D') || s[1:3] != "RR" {
+		return false
+	}
+	for i := 3; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// atois converts each string to an int, stopping at the first error
+func atois(ss []string, is ...*int) error {
+	for k, s := range ss {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("failed to read number %q: %v", s, err)
+		}
+		*is[k] = i
+	}
+	return nil
+}
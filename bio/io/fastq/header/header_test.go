@@ -0,0 +1,119 @@
+package header_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/io/fastq/header"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		line string
+		want header.Header
+	}{
+		{"@EAS139:136:FC706VJ:2:2104:15343:197393 1:Y:18:ATCACG", header.Header{
+			Format: header.Casava18, Instrument: "EAS139", Run: 136, Flowcell: "FC706VJ",
+			Lane: 2, Tile: 2104, X: 15343, Y: 197393, Read: 1, Filtered: true, Control: 18, Index: "ATCACG",
+		}},
+		{"A00123:8:H5KJ2DSXX:1:1101:10004:10019:ACGTACGT 2:N:0:ATCACG+GGTAAC", header.Header{
+			Format: header.Casava18, Instrument: "A00123", Run: 8, Flowcell: "H5KJ2DSXX",
+			Lane: 1, Tile: 1101, X: 10004, Y: 10019, UMI: "ACGTACGT", Read: 2, Index: "ATCACG+GGTAAC",
+		}},
+		{"HWUSI-EAS100R:6:73:941:1973#0/1", header.Header{
+			Format: header.Illumina, Instrument: "HWUSI-EAS100R",
+			Lane: 6, Tile: 73, X: 941, Y: 1973, Read: 1, Index: "0",
+		}},
+		{"HWUSI-EAS100R:6:73:941:1973#ATCACG", header.Header{
+			Format: header.Illumina, Instrument: "HWUSI-EAS100R",
+			Lane: 6, Tile: 73, X: 941, Y: 1973, Index: "ATCACG",
+		}},
+		{"@SRR001666.1 071112_SLXA-EAS1_s_7:5:1:817:345 length=36", header.Header{
+			Format: header.SRA, Accession: "SRR001666", Spot: 1, Length: 36,
+			Original:   "071112_SLXA-EAS1_s_7:5:1:817:345",
+			Instrument: "071112_SLXA-EAS1_s_7", Lane: 5, Tile: 1, X: 817, Y: 345,
+		}},
+		{"ERR000001.12.2 length=100", header.Header{
+			Format: header.SRA, Accession: "ERR000001", Spot: 12, Read: 2, Length: 100,
+		}},
+		{"SRR1.5/1 some original header", header.Header{
+			Format: header.SRA, Accession: "SRR1", Spot: 5, Read: 1, Original: "some original header",
+		}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.line, func(t *testing.T) {
+			got, err := header.Parse(tc.line)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Want: %+v, Got: %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"read1",
+		"EAS139:136:FC706VJ:2:2104:15343:197393 1:X:18:ATCACG",
+		"EAS139:136:FC706VJ:two:2104:15343:197393 1:N:18:ATCACG",
+		"HWUSI-EAS100R:6:73:941#0/1",
+		"XRR001666.1",
+		"SRR001666 length=36",
+	} {
+		t.Run(line, func(t *testing.T) {
+			if h, err := header.Parse(line); err == nil {
+				t.Errorf("Want: error, Got: %+v", h)
+			}
+		})
+	}
+}
+
+func TestParseGenerated(t *testing.T) {
+	recs, err := fastq.ReadMulti(
+		bytes.NewReader(fastq.TestGenMultiFastq(test.Seed, 100, 10, hashmap.NewDna())),
+		func(s string) (sequence.Interface, error) {
+			return immutable.NewDna(s)
+		},
+	)
+	if err != nil || len(recs) == 0 {
+		t.Fatalf("failed to generate records: %v", err)
+	}
+	for _, rec := range recs {
+		if h, err := header.Parse(rec.Header()); err != nil || h.Format != header.Casava18 {
+			t.Errorf("Want: Casava 1.8, Got: %v %v", h.Format, err)
+		}
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	tt := []struct {
+		index string
+		want  []string
+	}{
+		{"", []string{}},
+		{"ATCACG", []string{"ATCACG"}},
+		{"ATCACG+GGTAAC", []string{"ATCACG", "GGTAAC"}},
+	}
+	for _, tc := range tt {
+		if got := (header.Header{Index: tc.index}).Indexes(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Want: %v, Got: %v", tc.want, got)
+		}
+	}
+}
+
+func ExampleParse() {
+	h, err := header.Parse("@EAS139:136:FC706VJ:2:2104:15343:197393 1:Y:18:ATCACG")
+
+	fmt.Println(h.Format, h.Flowcell, h.Lane, h.Tile, h.Read, h.Filtered, h.Index, err)
+	// Output: Casava 1.8 FC706VJ 2 2104 1 true ATCACG <nil>
+}
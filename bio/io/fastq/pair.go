@@ -0,0 +1,157 @@
+package fastq
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sembio/go/bio/sequence"
+)
+
+// MateName splits a header into the name shared by both mates of a pair and
+// which mate it is (1 or 2, or 0 if the header does not say).
+// Mates are read from a "/1" or "/2" suffix of the name or a
+// Casava 1.8 comment such as "1:N:0:ATCACG".
+func MateName(header string) (string, int) {
+	name, comment := header, ""
+	if i := strings.IndexAny(header, " \t"); i >= 0 {
+		name, comment = header[:i], strings.TrimSpace(header[i+1:])
+	}
+	mate := 0
+	if n := len(name); n > 2 && name[n-2] == '/' && (name[n-1] == '1' || name[n-1] == '2') {
+		mate = int(name[n-1] - '0')
+		name = name[:n-2]
+	}
+	if len(comment) > 1 && (comment[0] == '1' || comment[0] == '2') && comment[1] == ':' {
+		mate = int(comment[0] - '0')
+	}
+	return name, mate
+}
+
+// CheckMates returns an error unless r1 and r2 share a name and,
+// where their headers say which mate they are, r1 is mate 1 and r2 is mate 2
+func CheckMates(r1, r2 Interface) error {
+	n1, m1 := MateName(r1.Header())
+	n2, m2 := MateName(r2.Header())
+	if n1 != n2 {
+		return fmt.Errorf("mate names do not match: %q and %q", n1, n2)
+	}
+	if (m1 != 0 && m1 != 1) || (m2 != 0 && m2 != 2) {
+		return fmt.Errorf("mates of %q are out of order: %d and %d", n1, m1, m2)
+	}
+	return nil
+}
+
+// PairScanner reads read pairs one at a time, either from two files of
+// mates in lockstep or from one interleaved file.
+// Scanning stops at the first error, which is reported by Err.
+type PairScanner struct {
+	s1, s2 *Scanner
+	r1, r2 Interface
+	err    error
+}
+
+// NewPairScanner generates a PairScanner reading mate 1 from r1 and mate 2 from r2
+func NewPairScanner(r1, r2 io.Reader, f sequence.Generator) *PairScanner {
+	return &PairScanner{
+		s1: NewScanner(r1, f),
+		s2: NewScanner(r2, f),
+	}
+}
+
+// NewInterleavedScanner generates a PairScanner reading alternating mates from r
+func NewInterleavedScanner(r io.Reader, f sequence.Generator) *PairScanner {
+	s := NewScanner(r, f)
+	return &PairScanner{
+		s1: s,
+		s2: s,
+	}
+}
+
+// Scan advances to the next pair, which is then available through Pair.
+// It returns false at the end of the input or upon an error, including when one
+// file of mates ends before the other or the mates do not match.
+func (p *PairScanner) Scan() bool {
+	if p.err != nil {
+		return false
+	}
+	p.r1, p.r2 = nil, nil
+	ok1 := p.s1.Scan()
+	if err := p.s1.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	r1 := p.s1.Record()
+	ok2 := p.s2.Scan()
+	if err := p.s2.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	r2 := p.s2.Record()
+	switch {
+	case !ok1 && !ok2:
+		return false
+	case !ok1:
+		p.err = fmt.Errorf("mate 1 reads ended before mate 2 reads")
+		return false
+	case !ok2:
+		p.err = fmt.Errorf("mate 2 reads ended before mate 1 reads")
+		return false
+	}
+	if err := CheckMates(r1, r2); err != nil {
+		p.err = err
+		return false
+	}
+	p.r1, p.r2 = r1, r2
+	return true
+}
+
+// Pair is the most recent pair read by Scan
+func (p *PairScanner) Pair() (Interface, Interface) {
+	return p.r1, p.r2
+}
+
+// Err is the first error encountered while scanning
+func (p *PairScanner) Err() error {
+	return p.err
+}
+
+// PairWriter writes read pairs, either split into two files of mates or interleaved in one
+type PairWriter struct {
+	w1, w2 io.Writer
+}
+
+// NewPairWriter generates a PairWriter writing mate 1 to w1 and mate 2 to w2
+func NewPairWriter(w1, w2 io.Writer) *PairWriter {
+	return &PairWriter{
+		w1: w1,
+		w2: w2,
+	}
+}
+
+// NewInterleavedWriter generates a PairWriter writing alternating mates to w
+func NewInterleavedWriter(w io.Writer) *PairWriter {
+	return &PairWriter{
+		w1: w,
+		w2: w,
+	}
+}
+
+// Write writes a pair after checking the mates match
+func (p *PairWriter) Write(r1, r2 Interface) error {
+	if err := CheckMates(r1, r2); err != nil {
+		return err
+	}
+	for _, rw := range []struct {
+		r Interface
+		w io.Writer
+	}{{r1, p.w1}, {r2, p.w2}} {
+		out := new(strings.Builder)
+		out.Grow(recordLength(rw.r))
+		writeRecord(out, rw.r.Header(), rw.r.Sequence(), rw.r.Quality())
+		if _, err := io.WriteString(rw.w, out.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
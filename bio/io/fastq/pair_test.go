@@ -0,0 +1,142 @@
+package fastq_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+func dna(s string) (sequence.Interface, error) {
+	return immutable.NewDna(s)
+}
+
+func TestMateName(t *testing.T) {
+	tt := []struct {
+		header string
+		name   string
+		mate   int
+	}{
+		{"HWUSI-EAS100R:6:73:941:1973#0/1", "HWUSI-EAS100R:6:73:941:1973#0", 1},
+		{"HWUSI-EAS100R:6:73:941:1973#0/2 extra", "HWUSI-EAS100R:6:73:941:1973#0", 2},
+		{"EAS139:136:FC706VJ:2:2104:15343:197393 1:Y:18:ATCACG", "EAS139:136:FC706VJ:2:2104:15343:197393", 1},
+		{"EAS139:136:FC706VJ:2:2104:15343:197393 2:N:0:ATCACG", "EAS139:136:FC706VJ:2:2104:15343:197393", 2},
+		{"SRR001666.1 071112_SLXA-EAS1_s_7:5:1:817:345 length=36", "SRR001666.1", 0},
+		{"read/3", "read/3", 0},
+		{"", "", 0},
+	}
+	for _, tc := range tt {
+		t.Run(tc.header, func(t *testing.T) {
+			if name, mate := fastq.MateName(tc.header); name != tc.name || mate != tc.mate {
+				t.Errorf("Want: %q %d, Got: %q %d", tc.name, tc.mate, name, mate)
+			}
+		})
+	}
+}
+
+func TestCheckMates(t *testing.T) {
+	rec := func(h string) fastq.Interface {
+		return fastq.New(h, "I", immutable.New("A"))
+	}
+	tt := []struct {
+		h1, h2 string
+		fails  bool
+	}{
+		{"r/1", "r/2", false},
+		{"r 1:N:0:ACGT", "r 2:N:0:ACGT", false},
+		{"r", "r", false},
+		{"r/1", "s/2", true},
+		{"r/2", "r/1", true},
+		{"r 1:N:0:ACGT", "r 1:N:0:ACGT", true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.h1+","+tc.h2, func(t *testing.T) {
+			if err := fastq.CheckMates(rec(tc.h1), rec(tc.h2)); (err != nil) != tc.fails {
+				t.Errorf("Want error: %v, Got: %v", tc.fails, err)
+			}
+		})
+	}
+}
+
+func TestPairScanner(t *testing.T) {
+	r1 := "@a/1\nAC\n+\nII\n@b/1\nGG\n+\nII\n"
+	r2 := "@a/2\nTT\n+\nII\n@b/2\nCC\n+\nII\n"
+	interleaved := "@a/1\nAC\n+\nII\n@a/2\nTT\n+\nII\n@b/1\nGG\n+\nII\n@b/2\nCC\n+\nII\n"
+	tt := []struct {
+		name  string
+		s     *fastq.PairScanner
+		pairs int
+		fails bool
+	}{
+		{"Split", fastq.NewPairScanner(strings.NewReader(r1), strings.NewReader(r2), dna), 2, false},
+		{"Interleaved", fastq.NewInterleavedScanner(strings.NewReader(interleaved), dna), 2, false},
+		{"Mate 2 ends early", fastq.NewPairScanner(strings.NewReader(r1), strings.NewReader(r2[:16]), dna), 1, true},
+		{"Mate 1 ends early", fastq.NewPairScanner(strings.NewReader(r1[:16]), strings.NewReader(r2), dna), 1, true},
+		{"Interleaved odd", fastq.NewInterleavedScanner(strings.NewReader(interleaved[:48]), dna), 1, true},
+		{"Mismatched names", fastq.NewPairScanner(strings.NewReader(r1), strings.NewReader(strings.Replace(r2, "b/2", "c/2", 1)), dna), 1, true},
+		{"Invalid record", fastq.NewPairScanner(strings.NewReader(r1), strings.NewReader(strings.Replace(r2, "CC", "CU", 1)), dna), 1, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			n := 0
+			for tc.s.Scan() {
+				a, b := tc.s.Pair()
+				if a.Sequence() == "" || b.Sequence() == "" {
+					t.Error("pairs should hold both mates")
+				}
+				n++
+			}
+			if n != tc.pairs {
+				t.Errorf("Want: %d pairs, Got: %d", tc.pairs, n)
+			}
+			if (tc.s.Err() != nil) != tc.fails {
+				t.Errorf("Want error: %v, Got: %v", tc.fails, tc.s.Err())
+			}
+		})
+	}
+}
+
+func TestPairWriter(t *testing.T) {
+	interleaved := "@a/1\nAC\n+\nII\n@a/2\nTT\n+\nII\n@b/1\nGG\n+\nII\n@b/2\nCC\n+\nII\n"
+	w1, w2, wi := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	split, inter := fastq.NewPairWriter(w1, w2), fastq.NewInterleavedWriter(wi)
+	s := fastq.NewInterleavedScanner(strings.NewReader(interleaved), dna)
+	for s.Scan() {
+		if err := split.Write(s.Pair()); err != nil {
+			t.Fatal(err)
+		}
+		if err := inter.Write(s.Pair()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Err() != nil {
+		t.Fatal(s.Err())
+	}
+	if wi.String() != interleaved {
+		t.Errorf("Want:\n%s\nGot:\n%s", interleaved, wi.String())
+	}
+	if w1.String() != "@a/1\nAC\n+\nII\n@b/1\nGG\n+\nII\n" || w2.String() != "@a/2\nTT\n+\nII\n@b/2\nCC\n+\nII\n" {
+		t.Errorf("split pairs were not written as read:\n%s\n%s", w1.String(), w2.String())
+	}
+	if err := split.Write(fastq.New("a/1", "I", immutable.New("A")), fastq.New("b/2", "I", immutable.New("A"))); err == nil {
+		t.Error("mismatched mates should not be written")
+	}
+}
+
+func ExamplePairScanner() {
+	r1 := "@read1/1\nACGT\n+\nIIII\n"
+	r2 := "@read1/2\nTTGA\n+\nIIII\n"
+	s := fastq.NewPairScanner(strings.NewReader(r1), strings.NewReader(r2), dna)
+	for s.Scan() {
+		a, b := s.Pair()
+		fmt.Println(a.Header(), a.Sequence(), b.Header(), b.Sequence())
+	}
+	fmt.Println(s.Err())
+	// Output:
+	// read1/1 ACGT read1/2 TTGA
+	// <nil>
+}
@@ -74,3 +74,40 @@ func TestReadMulti(t *testing.T) {
 	)
 	properties.TestingRun(t)
 }
+
+func TestReadErrors(t *testing.T) {
+	gen := func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	}
+	tt := []struct {
+		name  string
+		in    string
+		n     int
+		fails bool
+	}{
+		{"Bare second header", "@r1\nACGT\n+\nIIII\n", 1, false},
+		{"Quality shorter than sequence", "@r1\nACGT\n+\nIII\n", 0, true},
+		{"Quality longer than sequence", "@r1\nACGT\n+\nIIIII\n", 0, true},
+		{"Mismatch after a good record", "@r1\nACGT\n+\nIIII\n@r2\nGG\n+\nI\n", 1, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			recs, err := fastq.ReadMulti(strings.NewReader(tc.in), gen)
+			if len(recs) != tc.n {
+				t.Errorf("Want: %d records, Got: %d", tc.n, len(recs))
+			}
+			if (err != nil) != tc.fails {
+				t.Errorf("Want error: %v, Got: %v", tc.fails, err)
+			}
+		})
+	}
+}
+
+func TestReadSingleEmpty(t *testing.T) {
+	rec, err := fastq.ReadSingle(strings.NewReader(""), func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	})
+	if err == nil || rec != nil {
+		t.Errorf("Want an error for empty input, Got: %v %v", rec, err)
+	}
+}
@@ -0,0 +1,118 @@
+package fastq
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sembio/go/bio/sequence"
+)
+
+// MaxLineLength is the longest line a Scanner can read
+const MaxLineLength = 1 << 28
+
+// Scanner reads records from a FASTQ file one at a time using the generator f to validate the sequences.
+// Scanning stops at the first error, which is reported by Err.
+type Scanner struct {
+	br     *bufio.Scanner
+	f      sequence.Generator
+	record Interface
+	err    error
+}
+
+// NewScanner generates a Scanner reading from r
+func NewScanner(r io.Reader, f sequence.Generator) *Scanner {
+	br := bufio.NewScanner(r)
+	br.Buffer(make([]byte, 0, 64*1024), MaxLineLength)
+	br.Split(bufio.ScanLines)
+	return &Scanner{
+		br: br,
+		f:  f,
+	}
+}
+
+// Scan advances to the next record, which is then available through Record.
+// It returns false at the end of the input or upon an error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	s.record = nil
+
+	// Line 1: Header (skipping any blank lines before it)
+	line := ""
+	for line == "" {
+		if !s.br.Scan() {
+			s.err = s.br.Err()
+			return false
+		}
+		line = strings.TrimSpace(s.br.Text())
+	}
+	if line[0] != FastqHeaderPrefix {
+		s.err = fmt.Errorf("header line did not start with %q", FastqHeaderPrefix)
+		return false
+	}
+	header := line[1:]
+
+	// Line 2: Sequence
+	seq, ok := s.line("sequence")
+	if !ok {
+		return false
+	}
+	seqx, err := s.f(seq)
+	if err != nil {
+		s.err = err
+		return false
+	}
+
+	// Line 3: Header (should be empty or match Line 1 above)
+	plusLine, ok := s.line("second header")
+	if !ok {
+		return false
+	}
+	if plusLine == "" || plusLine[0] != FastqPreQualityHeaderPrefix {
+		s.err = fmt.Errorf("second header line did not start with %q", FastqPreQualityHeaderPrefix)
+		return false
+	}
+	if plusLine[1:] != "" && plusLine[1:] != header {
+		s.err = fmt.Errorf("first header:\n\t%q\ndid not match second header:\n\t%q", header, plusLine[1:])
+		return false
+	}
+
+	// Line 4: Quality
+	quality, ok := s.line("quality")
+	if !ok {
+		return false
+	}
+	if len(quality) != len(seq) {
+		s.err = fmt.Errorf("quality length [%d] did not match sequence length [%d] for %q",
+			len(quality), len(seq), header)
+		return false
+	}
+
+	s.record = New(header, quality, seqx)
+	return true
+}
+
+// line reads the next line of a record, setting an error if there is none
+func (s *Scanner) line(name string) (string, bool) {
+	if !s.br.Scan() {
+		s.err = s.br.Err()
+		if s.err == nil {
+			s.err = fmt.Errorf("record ended before %s line", name)
+		}
+		return "", false
+	}
+	return strings.TrimSpace(s.br.Text()), true
+}
+
+// Record is the most recent record read by Scan
+func (s *Scanner) Record() Interface {
+	return s.record
+}
+
+// Err is the first error encountered while scanning
+func (s *Scanner) Err() error {
+	return s.err
+}
@@ -0,0 +1,97 @@
+package fastq_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestScanner(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Scanner keeps each record separate",
+		prop.ForAll(
+			func(n uint) bool {
+				r := fastq.TestGenMultiFastq(
+					test.Seed,
+					n,
+					10,
+					hashmap.NewDna(),
+				)
+				s := fastq.NewScanner(bytes.NewReader(r), func(s string) (sequence.Interface, error) {
+					return immutable.NewDna(s)
+				})
+				for s.Scan() {
+					rec := s.Record()
+					if len(rec.Sequence()) != len(rec.Quality()) || len(rec.Sequence()) > int(n) {
+						return false
+					}
+				}
+				return s.Err() == nil
+			},
+			gen.UIntRange(2, 1000),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestScannerFormats(t *testing.T) {
+	gen := func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	}
+	tt := []struct {
+		name  string
+		in    string
+		n     int
+		fails bool
+	}{
+		{"Bare second header", "@r1\nACGT\n+\nIIII\n@r2\nGG\n+\nII\n", 2, false},
+		{"Repeated second header", "@r1\nACGT\n+r1\nIIII\n", 1, false},
+		{"Blank lines between records", "@r1\nACGT\n+\nIIII\n\n\n@r2\nGG\n+\nII\n", 2, false},
+		{"Mismatched second header", "@r1\nACGT\n+r2\nIIII\n", 0, true},
+		{"Missing second header", "@r1\nACGT\nIIII\n", 0, true},
+		{"Quality length mismatch", "@r1\nACGT\n+\nIII\n", 0, true},
+		{"Truncated record", "@r1\nACGT\n+\n", 0, true},
+		{"Invalid sequence", "@r1\nACGU\n+\nIIII\n", 0, true},
+		{"Missing header", "r1\nACGT\n+\nIIII\n", 0, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := fastq.NewScanner(strings.NewReader(tc.in), gen)
+			n := 0
+			for s.Scan() {
+				n++
+			}
+			if n != tc.n {
+				t.Errorf("Want: %d records, Got: %d", tc.n, n)
+			}
+			if (s.Err() != nil) != tc.fails {
+				t.Errorf("Want error: %v, Got: %v", tc.fails, s.Err())
+			}
+		})
+	}
+}
+
+func TestReadKeepsRecordsSeparate(t *testing.T) {
+	in := "@r1\nACGT\n+\nIIII\n@r2\nGG\n+\n##\n"
+	recs, err := fastq.ReadMulti(strings.NewReader(in), func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	})
+	if err != nil || len(recs) != 2 {
+		t.Fatalf("Want: 2 records, Got: %d %v", len(recs), err)
+	}
+	if recs[1].Header() != "r2" || recs[1].Sequence() != "GG" || recs[1].Quality() != "##" {
+		t.Errorf("second record was not read as written: %q %q %q",
+			recs[1].Header(), recs[1].Sequence(), recs[1].Quality())
+	}
+}
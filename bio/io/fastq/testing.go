@@ -49,6 +49,7 @@ func TestGenMultiFastq(seed int64, n, m uint, a alphabet.Interface) []byte {
 	b := make([]byte, 0)
 	for i := 0; i < nseqs; i++ {
 		b = append(b, TestGenFastq(seed, n, a)...)
+		b = append(b, '\n')
 	}
 	return b
 }
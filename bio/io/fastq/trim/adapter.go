@@ -0,0 +1,247 @@
+package trim
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Adapter is a named adapter sequence ligated to the 3' end of inserts
+type Adapter struct {
+	Name     string
+	Sequence *immutable.Dna
+}
+
+// NewAdapter generates an Adapter, validating its sequence as DNA
+func NewAdapter(name, seq string) (Adapter, error) {
+	dna, err := immutable.NewDna(strings.ToUpper(seq))
+	return Adapter{Name: name, Sequence: dna}, err
+}
+
+// mustAdapter generates the built-in Adapters
+func mustAdapter(name, seq string) Adapter {
+	a, err := NewAdapter(name, seq)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TruSeq are the Illumina TruSeq adapters read into by read 1 and read 2
+func TruSeq() []Adapter {
+	return []Adapter{
+		mustAdapter("TruSeq Read 1", "AGATCGGAAGAGCACACGTCTGAACTCCAGTCA"),
+		mustAdapter("TruSeq Read 2", "AGATCGGAAGAGCGTCGTGTAGGGAAAGAGTGT"),
+	}
+}
+
+// Nextera are the Illumina Nextera transposase adapters read into by read 1 and read 2
+func Nextera() []Adapter {
+	return []Adapter{
+		mustAdapter("Nextera Read 1", "CTGTCTCTTATACACATCTCCGAGCCCACGAGAC"),
+		mustAdapter("Nextera Read 2", "CTGTCTCTTATACACATCTGACGCTGCCGACGA"),
+	}
+}
+
+// SmallRNA is the Illumina small RNA 3' adapter
+func SmallRNA() []Adapter {
+	return []Adapter{
+		mustAdapter("Small RNA 3'", "TGGAATTCTCGGGTGCCAAGG"),
+	}
+}
+
+// Stats are how many reads, and bases of them, an Adapter was trimmed from
+type Stats struct {
+	Adapter string
+	Reads   uint
+	Bases   uint
+}
+
+// Remover finds and trims adapters allowing mismatches and partial overlaps at the 3' end
+type Remover struct {
+	adapters   []Adapter
+	errorRate  float64
+	minOverlap uint
+	minInsert  uint
+
+	mu    sync.Mutex
+	stats map[string]*Stats
+}
+
+// RemoverOption is a setting of a Remover
+type RemoverOption func(*Remover)
+
+// ErrorRateIs sets the most mismatches allowed per base of adapter matched (0.1 by default)
+func ErrorRateIs(rate float64) RemoverOption {
+	return func(r *Remover) {
+		r.errorRate = rate
+	}
+}
+
+// MinOverlapIs sets the fewest bases of adapter that must be matched
+// at the 3' end of a read (3 by default)
+func MinOverlapIs(n uint) RemoverOption {
+	return func(r *Remover) {
+		r.minOverlap = n
+	}
+}
+
+// MinInsertIs sets the fewest bases read pairs must overlap by for their
+// insert to be found from the overlap (15 by default)
+func MinInsertIs(n uint) RemoverOption {
+	return func(r *Remover) {
+		r.minInsert = n
+	}
+}
+
+// NewRemover generates a Remover for the adapters, whose names must be unique
+func NewRemover(adapters []Adapter, opts ...RemoverOption) (*Remover, error) {
+	r := &Remover{
+		adapters:   adapters,
+		errorRate:  0.1,
+		minOverlap: 3,
+		minInsert:  15,
+		stats:      make(map[string]*Stats),
+	}
+	for _, a := range adapters {
+		if _, ok := r.stats[a.Name]; ok {
+			return nil, fmt.Errorf("adapter name %q is not unique", a.Name)
+		}
+		r.stats[a.Name] = &Stats{Adapter: a.Name}
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// read is the bases of a read as a sequence, in upper case to compare with adapters
+func read(seq string) sequence.Interface {
+	return immutable.New(strings.ToUpper(seq))
+}
+
+// Func trims the earliest adapter found in a read along with everything after it
+func (r *Remover) Func() Func {
+	return func(seq string, phred []float64) (int, int) {
+		end := r.trim(read(seq))
+		return 0, end
+	}
+}
+
+// trim finds where the earliest adapter in seq starts (the length of seq if none),
+// recording it in the Stats
+func (r *Remover) trim(seq sequence.Interface) int {
+	end, which := r.find(seq, 0)
+	if which >= 0 {
+		r.record(which, int(seq.Length()-end))
+	}
+	return int(end)
+}
+
+// find finds where the earliest adapter in seq at or after from starts
+// (the length of seq if none) and which adapter it is (-1 if none)
+func (r *Remover) find(seq sequence.Interface, from uint) (uint, int) {
+	l := seq.Length()
+	for i := from; i < l && l-i >= r.minOverlap; i++ {
+		for j, a := range r.adapters {
+			n := a.Sequence.Length()
+			if l-i < n {
+				n = l - i
+			}
+			if r.matches(seq, i, a.Sequence, 0, n) {
+				return i, j
+			}
+		}
+	}
+	return l, -1
+}
+
+// matches reports whether n letters of a from i and of b from j differ by no more
+// than the error rate allows, with 'N' matching anything
+func (r *Remover) matches(a sequence.Interface, i uint, b sequence.Interface, j uint, n uint) bool {
+	allowed := int(r.errorRate * float64(n))
+	mismatches := 0
+	for k := uint(0); k < n; k++ {
+		x, _ := a.Position(i + k)
+		y, _ := b.Position(j + k)
+		if x != y && x != "N" && y != "N" {
+			mismatches++
+			if mismatches > allowed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// record adds a read trimmed of bases by adapter which to the Stats
+func (r *Remover) record(which, bases int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.stats[r.adapters[which].Name]
+	s.Reads++
+	s.Bases += uint(bases)
+}
+
+// Stats are the trimming statistics of each adapter, sorted by name
+func (r *Remover) Stats() []Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make([]Stats, 0, len(r.stats))
+	for _, s := range r.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Adapter < stats[j].Adapter
+	})
+	return stats
+}
+
+// PairFunc trims the adapters of a read pair. Where the reads overlap by at least the
+// minimum insert (see MinInsertIs) and the insert is shorter than both reads, both reads
+// are cut to the insert. Otherwise the adapters are searched for in each read as by Func.
+func (r *Remover) PairFunc() PairFunc {
+	return func(seq1, seq2 string) (int, int) {
+		read1 := read(seq1)
+		// read 2 is validated as DnaIupac so it can be reverse-complemented
+		if read2, err := immutable.NewDnaIupac(strings.ToUpper(seq2)); err == nil {
+			if insert, ok := r.insert(read1, read2); ok {
+				for _, s := range []sequence.Interface{read1, read2} {
+					if insert < s.Length() {
+						if _, which := r.find(s, insert); which >= 0 {
+							r.record(which, int(s.Length()-insert))
+						}
+					}
+				}
+				return int(insert), int(insert)
+			}
+		}
+		return r.trim(read1), r.trim(read(seq2))
+	}
+}
+
+// insert finds the length of the insert of a read pair when it is shorter than
+// both reads, being the longest overlap of read 1 with the reverse complement of read 2
+func (r *Remover) insert(read1 sequence.Interface, read2 sequence.RevComper) (uint, bool) {
+	rc, err := read2.RevComp()
+	if err != nil {
+		return 0, false
+	}
+	longest := read1.Length()
+	if rc.Length() < longest {
+		longest = rc.Length()
+	}
+	if longest == 0 {
+		return 0, false
+	}
+	for n := longest - 1; n >= r.minInsert && n > 0; n-- {
+		if r.matches(read1, 0, rc, rc.Length()-n, n) {
+			return n, true
+		}
+	}
+	return 0, false
+}
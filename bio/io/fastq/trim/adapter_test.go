@@ -0,0 +1,165 @@
+package trim_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/io/fastq/trim"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// remover is a Remover of adapters that must have unique names
+func remover(adapters []trim.Adapter, opts ...trim.RemoverOption) *trim.Remover {
+	r, err := trim.NewRemover(adapters, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestNewAdapter(t *testing.T) {
+	if _, err := trim.NewAdapter("bad", "ACGU"); err == nil {
+		t.Error("adapters should be validated as DNA")
+	}
+	a, err := trim.NewAdapter("lower", "acgt")
+	if err != nil || a.Sequence.String() != "ACGT" {
+		t.Errorf("Want: ACGT, Got: %v %v", a.Sequence, err)
+	}
+	for _, set := range [][]trim.Adapter{trim.TruSeq(), trim.Nextera(), trim.SmallRNA()} {
+		if len(set) == 0 {
+			t.Error("built-in adapter sets should not be empty")
+		}
+	}
+}
+
+func TestNewRemover(t *testing.T) {
+	a, _ := trim.NewAdapter("TruSeq Read 1", "ACGT")
+	if _, err := trim.NewRemover(append(trim.TruSeq(), a)); err == nil {
+		t.Error("adapters with the same name should error")
+	}
+}
+
+func TestRemoverFunc(t *testing.T) {
+	tt := []struct {
+		name string
+		seq  string
+		end  int
+	}{
+		{"Whole adapter", "ACGTACGTAGATCGGAAGAGCACACGTCTGAACTCCAGTCA", 8},
+		{"Adapter read through", "ACGTACGTAGATCGGAAGAGCACAC", 8},
+		{"Partial 3' overlap", "ACGTACGTACGTAGATC", 12},
+		{"Mismatch allowed", "ACGTACGTAGATCGGTAGAGCACACGTCTG", 8},
+		{"Lower case", "acgtacgtagatcggaagagcacac", 8},
+		{"No adapter", "ACGTACGTACGTACGT", 16},
+		{"Shorter than overlap", "AG", 2},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := remover(trim.TruSeq())
+			if _, end := r.Func()(tc.seq, nil); end != tc.end {
+				t.Errorf("Want: %d, Got: %d", tc.end, end)
+			}
+		})
+	}
+}
+
+func TestRemoverOptions(t *testing.T) {
+	seq := "ACGTACGTAGATCGGTAGAGCACACGTCTG"
+	if _, end := remover(trim.TruSeq(), trim.ErrorRateIs(0)).Func()(seq, nil); end != len(seq) {
+		t.Errorf("Want: no match without mismatches, Got: %d", end)
+	}
+	if _, end := remover(trim.TruSeq(), trim.MinOverlapIs(6)).Func()("ACGTACGTACGTAGATC", nil); end != 17 {
+		t.Errorf("Want: no match of 5 bases, Got: %d", end)
+	}
+}
+
+func TestRemoverStats(t *testing.T) {
+	r := remover(append(trim.TruSeq(), trim.SmallRNA()...))
+	f := r.Func()
+	f("ACGTACGTAGATCGGAAGAGCACAC", nil)
+	f("ACGTACGTACGTAGATC", nil)
+	f("ACGTTGGAATTCTCGGG", nil)
+	f("ACGTACGTACGTACGT", nil)
+	want := []trim.Stats{
+		{Adapter: "Small RNA 3'", Reads: 1, Bases: 13},
+		{Adapter: "TruSeq Read 1", Reads: 2, Bases: 22},
+		{Adapter: "TruSeq Read 2", Reads: 0, Bases: 0},
+	}
+	if got := r.Stats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestRemoverPairFunc(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+	adapters := trim.TruSeq()
+	a1, a2 := adapters[0].Sequence.String(), adapters[1].Sequence.String()
+
+	properties.Property("Pairs with short inserts are cut to the insert",
+		prop.ForAll(
+			func(insert int) bool {
+				rng := rand.New(rand.NewSource(test.Seed + int64(insert)))
+				b := make([]byte, insert)
+				for i := range b {
+					b[i] = "ACGT"[rng.Intn(4)]
+				}
+				r1 := (string(b) + a1)[:45]
+				r2 := (test.RevComp(string(b)) + a2)[:45]
+				end1, end2 := remover(adapters).PairFunc()(r1, r2)
+				return end1 == insert && end2 == insert
+			},
+			gen.IntRange(15, 44),
+		),
+	)
+	properties.TestingRun(t)
+
+	t.Run("Long inserts fall back to adapters", func(t *testing.T) {
+		r := remover(adapters)
+		end1, end2 := r.PairFunc()("ACGTACGTAGATCGGAAGAGCACAC", "TTTTTTTTTTTTTTTTTTTTT")
+		if end1 != 8 || end2 != 21 {
+			t.Errorf("Want: 8 21, Got: %d %d", end1, end2)
+		}
+	})
+}
+
+func TestTrimPair(t *testing.T) {
+	insert := "GATTACAGATTACAGATTACAGGG"
+	r1 := fastq.New("r/1", strings.Repeat("I", 40), immutable.New((insert + "AGATCGGAAGAGCACACGTCTGAACTCCAGTCA")[:40]))
+	r2 := fastq.New("r/2", strings.Repeat("I", 40), immutable.New((test.RevComp(insert) + "AGATCGGAAGAGCGTCGTGTAGGGAAAGAGTGT")[:40]))
+
+	trimmer := trim.New(quality.Illumina18, dna, trim.MinLength(20))
+	t1, t2, ok, err := trimmer.TrimPair(r1, r2, remover(trim.TruSeq()).PairFunc())
+	if err != nil || !ok || t1.Sequence() != insert || t2.Sequence() != test.RevComp(insert) ||
+		len(t1.Quality()) != len(insert) || len(t2.Quality()) != len(insert) {
+		t.Fatalf("Want: the insert, Got: %v %v %v %v", t1, t2, ok, err)
+	}
+
+	strict := trim.New(quality.Illumina18, dna, trim.MinLength(30))
+	if t1, t2, ok, err := strict.TrimPair(r1, r2, remover(trim.TruSeq()).PairFunc()); t1 != nil || t2 != nil || ok || err != nil {
+		t.Errorf("Want: discarded pair, Got: %v %v %v %v", t1, t2, ok, err)
+	}
+}
+
+func ExampleRemover_Stats() {
+	r, _ := trim.NewRemover(trim.TruSeq())
+	trimmer := trim.New(quality.Illumina18, dna, r.Func())
+
+	rec := fastq.New("read1", "IIIIIIIIIIIIIIIIII", immutable.New("ACGTACGTAGATCGGAAG"))
+	trimmed, _, _ := trimmer.Trim(rec)
+
+	fmt.Println(trimmed.Sequence())
+	fmt.Println(r.Stats())
+	// Output:
+	// ACGTACGT
+	// [{TruSeq Read 1 1 10} {TruSeq Read 2 0 0}]
+}
@@ -0,0 +1,5 @@
+/*
+Package trim removes low quality and unwanted ends from FASTQ reads,
+keeping their sequence and quality in sync
+*/
+package trim
@@ -0,0 +1,25 @@
+package trim
+
+// MinLength discards reads (trimming them to nothing) shorter than n bases
+func MinLength(n uint) Func {
+	return func(seq string, phred []float64) (int, int) {
+		if uint(len(seq)) < n {
+			return 0, 0
+		}
+		return 0, len(seq)
+	}
+}
+
+// Ns trims unknown bases ('N' or 'n') from both ends
+func Ns() Func {
+	return func(seq string, phred []float64) (int, int) {
+		start, end := 0, len(seq)
+		for start < end && (seq[start] == 'N' || seq[start] == 'n') {
+			start++
+		}
+		for end > start && (seq[end-1] == 'N' || seq[end-1] == 'n') {
+			end--
+		}
+		return start, end
+	}
+}
@@ -0,0 +1,31 @@
+package trim_test
+
+import (
+	"testing"
+
+	"github.com/sembio/go/bio/io/fastq/trim"
+)
+
+func TestFilterFuncs(t *testing.T) {
+	tt := []struct {
+		name  string
+		f     trim.Func
+		seq   string
+		start int
+		end   int
+	}{
+		{"MinLength keeps long reads", trim.MinLength(4), "ACGT", 0, 4},
+		{"MinLength discards short reads", trim.MinLength(5), "ACGT", 0, 0},
+		{"Ns trims both ends", trim.Ns(), "NnACNGTN", 2, 7},
+		{"Ns trims everything", trim.Ns(), "NNN", 3, 3},
+		{"Ns keeps clean reads", trim.Ns(), "ACGT", 0, 4},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := tc.f(tc.seq, make([]float64, len(tc.seq)))
+			if start != tc.start || end != tc.end {
+				t.Errorf("Want: [%d, %d), Got: [%d, %d)", tc.start, tc.end, start, end)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package trim
+
+// SlidingWindow trims the 3' end once the average quality of a window of size bases
+// falls below threshold, as Trimmomatic SLIDINGWINDOW does.
+// Bases at the start of the failing window that meet threshold themselves are kept.
+// Reads shorter than the window are treated as a single window.
+func SlidingWindow(size uint, threshold float64) Func {
+	return func(seq string, phred []float64) (int, int) {
+		w := int(size)
+		if w == 0 {
+			return 0, len(phred)
+		}
+		if w > len(phred) {
+			w = len(phred)
+		}
+		sum := 0.0
+		for i := 0; i < w; i++ {
+			sum += phred[i]
+		}
+		for i := 0; i+w <= len(phred); i++ {
+			if i > 0 {
+				sum += phred[i+w-1] - phred[i-1]
+			}
+			if sum/float64(w) < threshold {
+				end := i
+				for end < i+w && phred[end] >= threshold {
+					end++
+				}
+				return 0, end
+			}
+		}
+		return 0, len(phred)
+	}
+}
+
+// Mott trims the 3' end by the modified Mott algorithm used by BWA (bwa aln -q),
+// cutting where the running sum of threshold minus quality, taken from the 3' end, is highest
+func Mott(threshold float64) Func {
+	return func(seq string, phred []float64) (int, int) {
+		end, sum, max := len(phred), 0.0, 0.0
+		for i := len(phred) - 1; i >= 0; i-- {
+			sum += threshold - phred[i]
+			if sum < 0 {
+				break
+			}
+			if sum > max {
+				max, end = sum, i
+			}
+		}
+		return 0, end
+	}
+}
+
+// Leading trims bases with quality below threshold from the 5' end
+func Leading(threshold float64) Func {
+	return func(seq string, phred []float64) (int, int) {
+		start := 0
+		for start < len(phred) && phred[start] < threshold {
+			start++
+		}
+		return start, len(phred)
+	}
+}
+
+// Trailing trims bases with quality below threshold from the 3' end
+func Trailing(threshold float64) Func {
+	return func(seq string, phred []float64) (int, int) {
+		end := len(phred)
+		for end > 0 && phred[end-1] < threshold {
+			end--
+		}
+		return 0, end
+	}
+}
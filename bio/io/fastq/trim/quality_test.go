@@ -0,0 +1,56 @@
+package trim_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sembio/go/bio/io/fastq/trim"
+)
+
+func TestQualityFuncs(t *testing.T) {
+	tt := []struct {
+		name  string
+		f     trim.Func
+		phred []float64
+		start int
+		end   int
+	}{
+		{"SlidingWindow keeps passing bases of the failing window", trim.SlidingWindow(3, 20),
+			[]float64{30, 30, 30, 25, 10, 10}, 0, 4},
+		{"SlidingWindow cuts at the failing window", trim.SlidingWindow(2, 20),
+			[]float64{30, 30, 30, 10, 25, 10}, 0, 3},
+		{"SlidingWindow keeps good reads", trim.SlidingWindow(4, 20),
+			[]float64{30, 30, 30, 30, 30}, 0, 5},
+		{"SlidingWindow longer than the read", trim.SlidingWindow(4, 20),
+			[]float64{10, 10}, 0, 0},
+		{"SlidingWindow of zero", trim.SlidingWindow(0, 20),
+			[]float64{10, 10}, 0, 2},
+		{"Mott trims the 3' end", trim.Mott(20),
+			[]float64{30, 30, 30, 10, 10}, 0, 3},
+		{"Mott ignores low bases before a good 3' end", trim.Mott(20),
+			[]float64{30, 30, 5, 30, 30}, 0, 5},
+		{"Mott trims everything", trim.Mott(20),
+			[]float64{2, 2, 2}, 0, 0},
+		{"Leading", trim.Leading(3),
+			[]float64{2, 0, 3, 2, 40}, 2, 5},
+		{"Trailing", trim.Trailing(3),
+			[]float64{2, 3, 40, 2, 2}, 0, 3},
+		{"Trailing everything", trim.Trailing(3),
+			[]float64{2, 2}, 0, 0},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := tc.f(string(make([]byte, len(tc.phred))), tc.phred)
+			if start != tc.start || end != tc.end {
+				t.Errorf("Want: [%d, %d), Got: [%d, %d)", tc.start, tc.end, start, end)
+			}
+		})
+	}
+}
+
+func ExampleMott() {
+	start, end := trim.Mott(20)("ACGTA", []float64{30, 30, 30, 10, 10})
+
+	fmt.Println(start, end)
+	// Output: 0 3
+}
@@ -0,0 +1,99 @@
+package trim
+
+import (
+	"fmt"
+
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Func finds the part of a read to keep from its sequence and Phred quality scores
+// as the half-open range [start, end)
+type Func func(seq string, phred []float64) (start, end int)
+
+// PairFunc finds how much of the 5' end of each read of a pair to keep
+type PairFunc func(seq1, seq2 string) (end1, end2 int)
+
+// Trimmer applies each Func in turn, each to the part of the read kept by the last
+type Trimmer struct {
+	enc   quality.Encoding
+	f     sequence.Generator
+	funcs []Func
+}
+
+// New generates a Trimmer for reads with qualities in Encoding e
+// using the generator f to create the trimmed sequences
+func New(e quality.Encoding, f sequence.Generator, funcs ...Func) *Trimmer {
+	return &Trimmer{
+		enc:   e,
+		f:     f,
+		funcs: funcs,
+	}
+}
+
+// Trim trims a read, reporting whether any of it is kept.
+// Reads trimmed to nothing are discarded (returned as nil).
+func (t *Trimmer) Trim(r fastq.Interface) (fastq.Interface, bool, error) {
+	start, end, err := t.Range(r)
+	if err != nil || start == end {
+		return nil, false, err
+	}
+	seq := r.Sequence()[start:end]
+	seqx, err := t.f(seq)
+	if err != nil {
+		return nil, false, err
+	}
+	return fastq.New(r.Header(), r.Quality()[start:end], seqx), true, nil
+}
+
+// TrimPair trims a read pair, first by each PairFunc in turn then each read by
+// the Funcs of the Trimmer, reporting whether any of both reads is kept.
+// Pairs with either read trimmed to nothing are discarded (returned as nil).
+func (t *Trimmer) TrimPair(r1, r2 fastq.Interface, pfs ...PairFunc) (fastq.Interface, fastq.Interface, bool, error) {
+	end1, end2 := len(r1.Sequence()), len(r2.Sequence())
+	for _, pf := range pfs {
+		e1, e2 := pf(r1.Sequence()[:end1], r2.Sequence()[:end2])
+		end1, end2 = e1, e2
+	}
+	t1, ok1, err := t.Trim(fastq.New(r1.Header(), prefix(r1.Quality(), end1), immutable.New(r1.Sequence()[:end1])))
+	if err != nil || !ok1 {
+		return nil, nil, false, err
+	}
+	t2, ok2, err := t.Trim(fastq.New(r2.Header(), prefix(r2.Quality(), end2), immutable.New(r2.Sequence()[:end2])))
+	if err != nil || !ok2 {
+		return nil, nil, false, err
+	}
+	return t1, t2, true, nil
+}
+
+// prefix is the first n characters of s, or all of s if shorter
+func prefix(s string, n int) string {
+	if n > len(s) {
+		return s
+	}
+	return s[:n]
+}
+
+// Range is the half-open range [start, end) of a read that Trim keeps
+func (t *Trimmer) Range(r fastq.Interface) (start, end int, err error) {
+	scores, err := quality.Decode(r.Quality(), t.enc)
+	if err != nil {
+		return 0, 0, err
+	}
+	seq, phred := r.Sequence(), scores.Phred()
+	if len(seq) != len(phred) {
+		return 0, 0, fmt.Errorf("quality length [%d] did not match sequence length [%d] for %q",
+			len(phred), len(seq), r.Header())
+	}
+	start, end = 0, len(seq)
+	for _, f := range t.funcs {
+		if start == end {
+			break
+		}
+		s, e := f(seq[start:end], phred[start:end])
+		start, end = start+s, start+e
+	}
+	return start, end, nil
+}
@@ -0,0 +1,108 @@
+package trim_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/io/fastq/trim"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func dna(s string) (sequence.Interface, error) {
+	return immutable.NewDna(s)
+}
+
+func TestTrimmer(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	trimmer := trim.New(quality.Illumina18, dna,
+		trim.Leading(3),
+		trim.Trailing(3),
+		trim.SlidingWindow(4, 15),
+		trim.Mott(20),
+		trim.MinLength(2),
+	)
+	properties.Property("Trimmed reads keep sequence and quality in sync",
+		prop.ForAll(
+			func(n uint) bool {
+				r := fastq.TestGenMultiFastq(test.Seed, n, 10, hashmap.NewDna())
+				recs, err := fastq.ReadMulti(bytes.NewReader(r), dna)
+				if err != nil {
+					return false
+				}
+				for _, rec := range recs {
+					trimmed, ok, err := trimmer.Trim(rec)
+					if err != nil {
+						return false
+					}
+					if !ok {
+						continue
+					}
+					start := strings.Index(rec.Sequence(), trimmed.Sequence())
+					if len(trimmed.Sequence()) < 2 ||
+						len(trimmed.Sequence()) != len(trimmed.Quality()) ||
+						start < 0 ||
+						!strings.Contains(rec.Quality(), trimmed.Quality()) ||
+						trimmed.Header() != rec.Header() {
+						return false
+					}
+				}
+				return true
+			},
+			gen.UIntRange(1, 500),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestTrimmerErrors(t *testing.T) {
+	trimmer := trim.New(quality.Illumina18, dna, trim.Mott(20))
+	tt := []struct {
+		name string
+		rec  fastq.Interface
+	}{
+		{"Quality outside of encoding", fastq.New("r1", "II~I", immutable.New("ACGT"))},
+		{"Quality length mismatch", fastq.New("r1", "III", immutable.New("ACGT"))},
+		{"Invalid sequence", fastq.New("r1", "IIII", immutable.New("ACGU"))},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, ok, err := trimmer.Trim(tc.rec); err == nil || ok {
+				t.Errorf("Want: error, Got: %v %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestTrimmerDiscards(t *testing.T) {
+	trimmer := trim.New(quality.Sanger, dna, trim.Trailing(20), trim.MinLength(3))
+	rec, ok, err := trimmer.Trim(fastq.New("r1", "II##", immutable.New("ACGT")))
+	if rec != nil || ok || err != nil {
+		t.Errorf("Want: discarded read, Got: %v %v %v", rec, ok, err)
+	}
+}
+
+func ExampleTrimmer_Trim() {
+	trimmer := trim.New(quality.Illumina18, dna,
+		trim.Ns(),
+		trim.Leading(3),
+		trim.SlidingWindow(4, 20),
+		trim.MinLength(4),
+	)
+	rec := fastq.New("read1", "#IIIIIIII5#####", immutable.New("NACGTACGTACGTAC"))
+
+	trimmed, ok, err := trimmer.Trim(rec)
+	fmt.Println(trimmed.Sequence(), trimmed.Quality(), ok, err)
+	// Output: ACGTACGTA IIIIIIII5 true <nil>
+}
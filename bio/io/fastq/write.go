@@ -13,6 +13,7 @@ func Write(w io.Writer, is []Interface, n uint, f sequence.Generator) (uint, err
 	head, seq := "", ""
 	heads := make([]string, len(is))
 	seqs := make([]string, len(is))
+	quals := make([]string, len(is))
 	err, outLength := error(nil), 0
 	for i, s := range is {
 		if n != 0 && uint(i) == n {
@@ -25,7 +26,8 @@ func Write(w io.Writer, is []Interface, n uint, f sequence.Generator) (uint, err
 		} else {
 			heads[i] = head
 			seqs[i] = seq
-			outLength = outLength + len(head) + len("\n") + len(seq) + len("\n")
+			quals[i] = s.Quality()
+			outLength = outLength + recordLength(s)
 		}
 	}
 
@@ -36,7 +38,7 @@ func Write(w io.Writer, is []Interface, n uint, f sequence.Generator) (uint, err
 		if heads[i] == "" || seqs[i] == "" {
 			break
 		}
-		out.WriteString(heads[i] + "\n" + seqs[i] + "\n")
+		writeRecord(out, heads[i], seqs[i], quals[i])
 		nRecs++
 	}
 	w.Write([]byte(out.String()))
@@ -53,3 +55,19 @@ func WriteSingle(w io.Writer, i Interface, f sequence.Generator) (uint, error) {
 func WriteMulti(w io.Writer, is []Interface, f sequence.Generator) (uint, error) {
 	return Write(w, is, 0, f)
 }
+
+// recordLength is the number of bytes record i takes when written
+func recordLength(i Interface) int {
+	return len("@") + len(i.Header()) + len("\n") +
+		len(i.Sequence()) + len("\n") +
+		len("+\n") +
+		len(i.Quality()) + len("\n")
+}
+
+// writeRecord writes the four lines of a record, leaving the second header empty
+func writeRecord(out *strings.Builder, head, seq, qual string) {
+	out.WriteByte(FastqHeaderPrefix)
+	out.WriteString(head + "\n" + seq + "\n")
+	out.WriteByte(FastqPreQualityHeaderPrefix)
+	out.WriteString("\n" + qual + "\n")
+}
@@ -0,0 +1,52 @@
+package fastq_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestWriteMulti(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+	f := func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	}
+
+	properties.Property("Written records read back the same",
+		prop.ForAll(
+			func(n uint) bool {
+				in, err := fastq.ReadMulti(bytes.NewReader(fastq.TestGenMultiFastq(test.Seed, n, 10, hashmap.NewDna())), f)
+				if err != nil {
+					return false
+				}
+				out := new(bytes.Buffer)
+				if written, err := fastq.WriteMulti(out, in, f); err != nil || written != uint(len(in)) {
+					return false
+				}
+				back, err := fastq.ReadMulti(out, f)
+				if err != nil || len(back) != len(in) {
+					return false
+				}
+				for i := range in {
+					if in[i].Header() != back[i].Header() ||
+						in[i].Sequence() != back[i].Sequence() ||
+						in[i].Quality() != back[i].Quality() {
+						return false
+					}
+				}
+				return true
+			},
+			gen.UIntRange(2, 500),
+		),
+	)
+	properties.TestingRun(t)
+}
@@ -0,0 +1,152 @@
+package kmer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// Counter counts the k-mers of sequences, optionally as canonical k-mers
+type Counter struct {
+	k         uint
+	canonical bool
+	counts    map[Kmer]uint
+	total     uint
+}
+
+// NewCounter generates a Counter of k-mers of k bases. Canonical counters count each
+// k-mer along with its reverse complement so counts do not depend on strand.
+func NewCounter(k uint, canonical bool) (*Counter, error) {
+	if err := checkK(k); err != nil {
+		return nil, err
+	}
+	return &Counter{
+		k:         k,
+		canonical: canonical,
+		counts:    make(map[Kmer]uint),
+	}, nil
+}
+
+// K is the length of the k-mers counted
+func (c *Counter) K() uint {
+	return c.k
+}
+
+// Canonical is whether k-mers are counted in their canonical form
+func (c *Counter) Canonical() bool {
+	return c.canonical
+}
+
+// Add counts the k-mers of s
+func (c *Counter) Add(s sequence.Interface) error {
+	seq, err := s.Range(0, s.Length())
+	if err != nil {
+		return err
+	}
+	c.add(seq)
+	return nil
+}
+
+// AddRecord counts the k-mers of the sequence of a FASTA or FASTQ record
+func (c *Counter) AddRecord(r fasta.Interface) {
+	c.add(r.Sequence())
+}
+
+// add counts the k-mers of seq
+func (c *Counter) add(seq string) {
+	it, _ := newIterator(seq, c.k)
+	for it.Next() {
+		if c.canonical {
+			c.counts[it.Canonical()]++
+		} else {
+			c.counts[it.Kmer()]++
+		}
+		c.total++
+	}
+}
+
+// Count is how many times k-mer x was counted (its canonical form for canonical counters)
+func (c *Counter) Count(x Kmer) uint {
+	if c.canonical {
+		x = Canonical(x, c.k)
+	}
+	return c.counts[x]
+}
+
+// Distinct is the number of distinct k-mers counted
+func (c *Counter) Distinct() uint {
+	return uint(len(c.counts))
+}
+
+// Total is the number of k-mers counted
+func (c *Counter) Total() uint {
+	return c.total
+}
+
+// Kmers are the distinct k-mers counted in ascending order
+func (c *Counter) Kmers() []Kmer {
+	xs := make([]Kmer, 0, len(c.counts))
+	for x := range c.counts {
+		xs = append(xs, x)
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i] < xs[j]
+	})
+	return xs
+}
+
+// Merge adds the counts of another Counter of the same k and form
+func (c *Counter) Merge(o *Counter) error {
+	if o.k != c.k || o.canonical != c.canonical {
+		return fmt.Errorf("cannot merge counts of %d-mers (canonical: %v) into %d-mers (canonical: %v)",
+			o.k, o.canonical, c.k, c.canonical)
+	}
+	for x, n := range o.counts {
+		c.counts[x] += n
+	}
+	c.total += o.total
+	return nil
+}
+
+// Bin is how many distinct k-mers were counted a number of times
+type Bin struct {
+	Multiplicity uint
+	Kmers        uint
+}
+
+// Spectrum is the k-mer spectrum: how many distinct k-mers were counted each
+// number of times, in ascending order of multiplicity
+func (c *Counter) Spectrum() []Bin {
+	hist := make(map[uint]uint)
+	for _, n := range c.counts {
+		hist[n]++
+	}
+	return bins(hist)
+}
+
+// bins sorts a histogram of multiplicities into a spectrum
+func bins(hist map[uint]uint) []Bin {
+	bins := make([]Bin, 0, len(hist))
+	for m, n := range hist {
+		bins = append(bins, Bin{Multiplicity: m, Kmers: n})
+	}
+	sort.Slice(bins, func(i, j int) bool {
+		return bins[i].Multiplicity < bins[j].Multiplicity
+	})
+	return bins
+}
+
+// WriteSpectrum writes the Spectrum as lines of multiplicity and number of
+// distinct k-mers separated by a space, as in the output of jellyfish histo
+func (c *Counter) WriteSpectrum(w io.Writer) error {
+	out := new(strings.Builder)
+	for _, b := range c.Spectrum() {
+		fmt.Fprintf(out, "%d %d\n", b.Multiplicity, b.Kmers)
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,112 @@
+package kmer_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestCounter(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Counts match counting each window",
+		prop.ForAll(
+			func(n, k uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGTN"))
+				c, _ := kmer.NewCounter(k, false)
+				if c.Add(immutable.New(s)) != nil {
+					return false
+				}
+				want := make(map[string]uint)
+				total := uint(0)
+				for i := 0; i+int(k) <= len(s); i++ {
+					if x, err := kmer.Encode(s[i : i+int(k)]); err == nil {
+						want[kmer.Decode(x, k)]++
+						total++
+					}
+				}
+				got := make(map[string]uint)
+				for _, x := range c.Kmers() {
+					got[kmer.Decode(x, k)] = c.Count(x)
+				}
+				return reflect.DeepEqual(want, got) && c.Total() == total && c.Distinct() == uint(len(want))
+			},
+			gen.UIntRange(0, 300),
+			gen.UIntRange(1, 8),
+		),
+	)
+	properties.Property("Canonical counts do not depend on strand",
+		prop.ForAll(
+			func(n, k uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGT"))
+				fwd, _ := kmer.NewCounter(k, true)
+				rev, _ := kmer.NewCounter(k, true)
+				fwd.Add(immutable.New(s))
+				rev.Add(immutable.New(test.RevComp(s)))
+				return reflect.DeepEqual(fwd.Kmers(), rev.Kmers()) &&
+					reflect.DeepEqual(fwd.Spectrum(), rev.Spectrum())
+			},
+			gen.UIntRange(1, 300),
+			gen.UIntRange(1, kmer.MaxK),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestCounterSpectrum(t *testing.T) {
+	c, _ := kmer.NewCounter(2, false)
+	c.AddRecord(fastq.New("r1", "IIIIII", immutable.New("AAAACG")))
+	want := []kmer.Bin{{Multiplicity: 1, Kmers: 2}, {Multiplicity: 3, Kmers: 1}}
+	if got := c.Spectrum(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestCounterMerge(t *testing.T) {
+	a, _ := kmer.NewCounter(3, true)
+	b, _ := kmer.NewCounter(3, true)
+	a.Add(immutable.New("ACGTT"))
+	b.Add(immutable.New("AACGT"))
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	x, _ := kmer.Encode("ACG")
+	if a.Count(x) != 4 || a.Total() != 6 {
+		t.Errorf("Want: ACG counted 4 times of 6, Got: %d of %d", a.Count(x), a.Total())
+	}
+	for _, o := range []struct {
+		k         uint
+		canonical bool
+	}{{4, true}, {3, false}} {
+		c, _ := kmer.NewCounter(o.k, o.canonical)
+		if err := a.Merge(c); err == nil {
+			t.Errorf("Want: error merging %d-mers (canonical: %v)", o.k, o.canonical)
+		}
+	}
+	if _, err := kmer.NewCounter(0, true); err == nil {
+		t.Error("Want: error for k of 0")
+	}
+}
+
+func ExampleCounter_WriteSpectrum() {
+	c, _ := kmer.NewCounter(3, true)
+	c.Add(immutable.New("ACGTACGNNACG"))
+	x, _ := kmer.Encode("CGT")
+
+	fmt.Println(c.Count(x), c.Distinct(), c.Total())
+	c.WriteSpectrum(os.Stdout)
+	// Output:
+	// 4 2 6
+	// 2 1
+	// 4 1
+}
@@ -0,0 +1,7 @@
+/*
+Package kmer iterates and counts the k-mers of nucleotide sequences.
+
+A Kmer of up to 32 bases is packed 2 bits per base into a uint64 (A=0, C=1, G=2, T=3,
+with U read as T). Windows holding any other letter, such as the ambiguous N, are skipped.
+*/
+package kmer
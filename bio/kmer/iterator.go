@@ -0,0 +1,82 @@
+package kmer
+
+import (
+	"github.com/sembio/go/bio/sequence"
+)
+
+// Iterator steps through the k-mers of a sequence with a rolling 2-bit encoding
+// of both strands, skipping windows holding letters that are not bases
+type Iterator struct {
+	seq      string
+	k        uint
+	mask     uint64
+	i        int
+	valid    uint
+	fwd, rev uint64
+}
+
+// NewIterator generates an Iterator over the k-mers of s
+func NewIterator(s sequence.Interface, k uint) (*Iterator, error) {
+	seq, err := s.Range(0, s.Length())
+	if err != nil {
+		return nil, err
+	}
+	return newIterator(seq, k)
+}
+
+// newIterator generates an Iterator over the k-mers of seq
+func newIterator(seq string, k uint) (*Iterator, error) {
+	if err := checkK(k); err != nil {
+		return nil, err
+	}
+	return &Iterator{
+		seq:  seq,
+		k:    k,
+		mask: mask(k),
+	}, nil
+}
+
+// Next advances to the next k-mer, returning false at the end of the sequence
+func (it *Iterator) Next() bool {
+	shift := 2 * (it.k - 1)
+	for it.i < len(it.seq) {
+		c := code[it.seq[it.i]]
+		it.i++
+		if c > 3 {
+			it.valid = 0
+			continue
+		}
+		it.fwd = (it.fwd<<2 | uint64(c)) & it.mask
+		it.rev = it.rev>>2 | (3-uint64(c))<<shift
+		if it.valid < it.k {
+			it.valid++
+		}
+		if it.valid == it.k {
+			return true
+		}
+	}
+	return false
+}
+
+// Kmer is the current k-mer
+func (it *Iterator) Kmer() Kmer {
+	return Kmer(it.fwd)
+}
+
+// RevComp is the reverse complement of the current k-mer
+func (it *Iterator) RevComp() Kmer {
+	return Kmer(it.rev)
+}
+
+// Canonical is the canonical form of the current k-mer
+func (it *Iterator) Canonical() Kmer {
+	if it.rev < it.fwd {
+		return Kmer(it.rev)
+	}
+	return Kmer(it.fwd)
+}
+
+// Position is where the current k-mer starts in the sequence
+func (it *Iterator) Position() uint {
+	return uint(it.i) - it.k
+}
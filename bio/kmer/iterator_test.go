@@ -0,0 +1,76 @@
+package kmer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestIterator(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Iterator visits every window of bases in order",
+		prop.ForAll(
+			func(n, k uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGTACGTACGTN"))
+				it, err := kmer.NewIterator(immutable.New(s), k)
+				if err != nil {
+					return false
+				}
+				for i := 0; i+int(k) <= len(s); i++ {
+					w := s[i : i+int(k)]
+					if strings.Contains(w, "N") {
+						continue
+					}
+					if !it.Next() || it.Position() != uint(i) ||
+						kmer.Decode(it.Kmer(), k) != w ||
+						kmer.Decode(it.RevComp(), k) != test.RevComp(w) ||
+						it.Canonical() != kmer.Canonical(it.Kmer(), k) {
+						return false
+					}
+				}
+				return !it.Next()
+			},
+			gen.UIntRange(0, 200),
+			gen.UIntRange(1, kmer.MaxK),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestIteratorErrors(t *testing.T) {
+	for _, k := range []uint{0, kmer.MaxK + 1} {
+		if _, err := kmer.NewIterator(immutable.New("ACGT"), k); err == nil {
+			t.Errorf("Want: error for k of %d", k)
+		}
+	}
+}
+
+func ExampleIterator() {
+	it, _ := kmer.NewIterator(immutable.New("ACGNTTGA"), 3)
+	for it.Next() {
+		fmt.Println(it.Position(), kmer.Decode(it.Kmer(), 3), kmer.Decode(it.Canonical(), 3))
+	}
+	// Output:
+	// 0 ACG ACG
+	// 4 TTG CAA
+	// 5 TGA TCA
+}
+
+func BenchmarkIterator(b *testing.B) {
+	s := immutable.New(test.RandomStringFromRunes(test.Seed, 100000, []rune("ACGT")))
+	for i := 0; i < b.N; i++ {
+		it, _ := kmer.NewIterator(s, 31)
+		for it.Next() {
+			it.Canonical()
+		}
+	}
+}
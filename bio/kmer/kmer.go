@@ -0,0 +1,87 @@
+package kmer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxK is the longest k-mer that fits in a Kmer
+const MaxK = 32
+
+// Kmer is a k-mer packed 2 bits per base with the first base in the highest bits
+type Kmer uint64
+
+// code is the 2-bit code of each base, or 4 for any letter that is not a base
+var code = func() [256]byte {
+	var c [256]byte
+	for i := range c {
+		c[i] = 4
+	}
+	for i, b := range "ACGT" {
+		c[b] = byte(i)
+		c[strings.ToLower(string(b))[0]] = byte(i)
+	}
+	c['U'], c['u'] = 3, 3
+	return c
+}()
+
+// checkK returns an error unless k is from 1 to MaxK
+func checkK(k uint) error {
+	if k == 0 || k > MaxK {
+		return fmt.Errorf("k [%d] must be from 1 to %d", k, MaxK)
+	}
+	return nil
+}
+
+// mask keeps the lowest 2k bits
+func mask(k uint) uint64 {
+	if k == MaxK {
+		return ^uint64(0)
+	}
+	return (uint64(1) << (2 * k)) - 1
+}
+
+// Encode packs a k-mer of up to MaxK bases
+func Encode(s string) (Kmer, error) {
+	if err := checkK(uint(len(s))); err != nil {
+		return 0, err
+	}
+	x := uint64(0)
+	for i := 0; i < len(s); i++ {
+		c := code[s[i]]
+		if c > 3 {
+			return 0, fmt.Errorf("letter %q at position %d is not a base", s[i], i)
+		}
+		x = x<<2 | uint64(c)
+	}
+	return Kmer(x), nil
+}
+
+// Decode unpacks a k-mer of k bases
+func Decode(x Kmer, k uint) string {
+	b := make([]byte, k)
+	for i := int(k) - 1; i >= 0; i-- {
+		b[i] = "ACGT"[x&3]
+		x >>= 2
+	}
+	return string(b)
+}
+
+// RevComp is the reverse complement of a k-mer of k bases
+func RevComp(x Kmer, k uint) Kmer {
+	rc := uint64(0)
+	for i := uint(0); i < k; i++ {
+		rc = rc<<2 | (3 - uint64(x&3))
+		x >>= 2
+	}
+	return Kmer(rc)
+}
+
+// Canonical is the lesser of a k-mer of k bases and its reverse complement,
+// so a k-mer and its reverse complement share the same canonical k-mer
+func Canonical(x Kmer, k uint) Kmer {
+	if rc := RevComp(x, k); rc < x {
+		return rc
+	}
+	return x
+}
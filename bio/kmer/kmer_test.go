@@ -0,0 +1,69 @@
+package kmer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestEncode(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Decode(Encode(s)) is s",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGT"))
+				x, err := kmer.Encode(s)
+				return err == nil && kmer.Decode(x, n) == s
+			},
+			gen.UIntRange(1, kmer.MaxK),
+		),
+	)
+	properties.Property("RevComp matches the reverse complement of the sequence",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGT"))
+				x, _ := kmer.Encode(s)
+				return kmer.Decode(kmer.RevComp(x, n), n) == test.RevComp(s)
+			},
+			gen.UIntRange(1, kmer.MaxK),
+		),
+	)
+	properties.Property("A k-mer and its reverse complement share a canonical k-mer",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGT"))
+				x, _ := kmer.Encode(s)
+				c := kmer.Canonical(x, n)
+				return c == kmer.Canonical(kmer.RevComp(x, n), n) && c <= x
+			},
+			gen.UIntRange(1, kmer.MaxK),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	for _, s := range []string{"", "ACGTN", "ACGTACGTACGTACGTACGTACGTACGTACGTA"} {
+		if _, err := kmer.Encode(s); err == nil {
+			t.Errorf("Want: error encoding %q", s)
+		}
+	}
+	if x, err := kmer.Encode("acgu"); err != nil || kmer.Decode(x, 4) != "ACGT" {
+		t.Errorf("Want: lower case and U read as bases, Got: %v", err)
+	}
+}
+
+func ExampleCanonical() {
+	x, _ := kmer.Encode("TTGCA")
+	c := kmer.Canonical(x, 5)
+
+	fmt.Println(kmer.Decode(c, 5))
+	// Output: TGCAA
+}
@@ -0,0 +1,164 @@
+package kmer
+
+import (
+	"fmt"
+
+	"github.com/sembio/go/bio/sequence"
+)
+
+// Hash orders k-mers of k bases when choosing minimizers and syncmers
+type Hash func(x Kmer, k uint) uint64
+
+// Identity orders k-mers lexicographically
+func Identity(x Kmer, k uint) uint64 {
+	return uint64(x)
+}
+
+// Mix orders k-mers by the invertible integer hash used by minimap2, which
+// keeps low-complexity k-mers such as runs of A from being chosen too often
+func Mix(x Kmer, k uint) uint64 {
+	m := mask(k)
+	h := uint64(x)
+	h = (^h + (h << 21)) & m
+	h = h ^ h>>24
+	h = ((h + (h << 3)) + (h << 8)) & m
+	h = h ^ h>>14
+	h = ((h + (h << 2)) + (h << 4)) & m
+	h = h ^ h>>28
+	h = (h + (h << 31)) & m
+	return h
+}
+
+// Seed is a k-mer chosen from a sequence
+type Seed struct {
+	Kmer     Kmer
+	Position uint
+	Hash     uint64
+}
+
+// seeds lists every k-mer of s (canonical if canonical) with its hash, split into
+// runs of consecutive k-mers wherever a letter that is not a base intervenes
+func seeds(s sequence.Interface, k uint, canonical bool, h Hash) ([][]Seed, error) {
+	it, err := NewIterator(s, k)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([][]Seed, 0)
+	var run []Seed
+	for it.Next() {
+		x := it.Kmer()
+		if canonical {
+			x = it.Canonical()
+		}
+		if len(run) != 0 && run[len(run)-1].Position+1 != it.Position() {
+			runs = append(runs, run)
+			run = nil
+		}
+		run = append(run, Seed{Kmer: x, Position: it.Position(), Hash: h(x, k)})
+	}
+	if len(run) != 0 {
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// Minimizers are the (w,k)-minimizers of s: the k-mer with the lowest hash (the
+// leftmost if tied) of every window of w consecutive k-mers, each listed once in
+// order of position. Windows holding letters that are not bases are skipped.
+func Minimizers(s sequence.Interface, w, k uint, canonical bool, h Hash) ([]Seed, error) {
+	if w == 0 {
+		return nil, fmt.Errorf("window of 0 k-mers has no minimizer")
+	}
+	runs, err := seeds(s, k, canonical, h)
+	if err != nil {
+		return nil, err
+	}
+	mins := make([]Seed, 0)
+	for _, run := range runs {
+		// deque holds the indexes of candidates in ascending order of hash
+		deque := make([]int, 0, w)
+		for i := range run {
+			for len(deque) != 0 && run[deque[len(deque)-1]].Hash > run[i].Hash {
+				deque = deque[:len(deque)-1]
+			}
+			deque = append(deque, i)
+			if deque[0] <= i-int(w) {
+				deque = deque[1:]
+			}
+			if i+1 < int(w) {
+				continue
+			}
+			if m := run[deque[0]]; len(mins) == 0 || mins[len(mins)-1].Position != m.Position {
+				mins = append(mins, m)
+			}
+		}
+	}
+	return mins, nil
+}
+
+// smers finds the position of the s-mer of k-mer x with the lowest hash (the
+// leftmost if tied)
+func smers(x Kmer, k, sub uint, h Hash) uint {
+	m := Kmer(mask(sub))
+	best, at := uint64(0), uint(0)
+	for j := uint(0); j+sub <= k; j++ {
+		v := h((x>>(2*(k-sub-j)))&m, sub)
+		if j == 0 || v < best {
+			best, at = v, j
+		}
+	}
+	return at
+}
+
+// checkSyncmer returns an error unless s-mers of sub bases fit in k-mers of k bases
+func checkSyncmer(k, sub uint) error {
+	if err := checkK(k); err != nil {
+		return err
+	}
+	if sub == 0 || sub >= k {
+		return fmt.Errorf("s-mer length [%d] must be from 1 to k-1 [%d]", sub, k-1)
+	}
+	return nil
+}
+
+// OpenSyncmers are the open syncmers of s: k-mers whose s-mer of sub bases with
+// the lowest hash starts at offset t of the k-mer
+func OpenSyncmers(s sequence.Interface, k, sub, t uint, canonical bool, h Hash) ([]Seed, error) {
+	if err := checkSyncmer(k, sub); err != nil {
+		return nil, err
+	}
+	if t > k-sub {
+		return nil, fmt.Errorf("offset [%d] must be from 0 to k-s [%d]", t, k-sub)
+	}
+	return syncmers(s, k, sub, canonical, h, func(at uint) bool {
+		return at == t
+	})
+}
+
+// ClosedSyncmers are the closed syncmers of s: k-mers whose s-mer of sub bases
+// with the lowest hash is at their start or end
+func ClosedSyncmers(s sequence.Interface, k, sub uint, canonical bool, h Hash) ([]Seed, error) {
+	if err := checkSyncmer(k, sub); err != nil {
+		return nil, err
+	}
+	return syncmers(s, k, sub, canonical, h, func(at uint) bool {
+		return at == 0 || at == k-sub
+	})
+}
+
+// syncmers lists the k-mers of s whose lowest s-mer is at a chosen offset
+func syncmers(s sequence.Interface, k, sub uint, canonical bool, h Hash, chosen func(uint) bool) ([]Seed, error) {
+	runs, err := seeds(s, k, canonical, h)
+	if err != nil {
+		return nil, err
+	}
+	syncs := make([]Seed, 0)
+	for _, run := range runs {
+		for _, seed := range run {
+			if chosen(smers(seed.Kmer, k, sub, h)) {
+				syncs = append(syncs, seed)
+			}
+		}
+	}
+	return syncs, nil
+}
@@ -0,0 +1,210 @@
+package kmer_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// naiveSeeds lists the k-mers of s at each position (false where not all bases)
+func naiveSeeds(s string, k uint, canonical bool, h kmer.Hash) ([]kmer.Seed, []bool) {
+	seeds, ok := make([]kmer.Seed, 0), make([]bool, 0)
+	for i := 0; i+int(k) <= len(s); i++ {
+		x, err := kmer.Encode(s[i : i+int(k)])
+		if canonical {
+			x = kmer.Canonical(x, k)
+		}
+		seeds = append(seeds, kmer.Seed{Kmer: x, Position: uint(i), Hash: h(x, k)})
+		ok = append(ok, err == nil)
+	}
+	return seeds, ok
+}
+
+// naiveMinimizers checks every window of w k-mers
+func naiveMinimizers(s string, w, k uint, canonical bool, h kmer.Hash) []kmer.Seed {
+	seeds, ok := naiveSeeds(s, k, canonical, h)
+	mins := make([]kmer.Seed, 0)
+window:
+	for i := 0; i+int(w) <= len(seeds); i++ {
+		best := seeds[i]
+		for j := i; j < i+int(w); j++ {
+			if !ok[j] {
+				continue window
+			}
+			if seeds[j].Hash < best.Hash {
+				best = seeds[j]
+			}
+		}
+		if len(mins) == 0 || mins[len(mins)-1].Position != best.Position {
+			mins = append(mins, best)
+		}
+	}
+	return mins
+}
+
+// naiveSyncmers decodes every s-mer of every k-mer
+func naiveSyncmers(s string, k, sub uint, h kmer.Hash, chosen func(uint) bool) []kmer.Seed {
+	seeds, ok := naiveSeeds(s, k, false, h)
+	syncs := make([]kmer.Seed, 0)
+	for i, seed := range seeds {
+		if !ok[i] {
+			continue
+		}
+		str := kmer.Decode(seed.Kmer, k)
+		at, best := uint(0), uint64(0)
+		for j := uint(0); j+sub <= k; j++ {
+			x, _ := kmer.Encode(str[j : j+sub])
+			if v := h(x, sub); j == 0 || v < best {
+				at, best = j, v
+			}
+		}
+		if chosen(at) {
+			syncs = append(syncs, seed)
+		}
+	}
+	return syncs
+}
+
+func TestMinimizers(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	for _, h := range []struct {
+		name string
+		f    kmer.Hash
+	}{{"Identity", kmer.Identity}, {"Mix", kmer.Mix}} {
+		f := h.f
+		properties.Property("Minimizers match checking every window with "+h.name,
+			prop.ForAll(
+				func(n, w, k uint, canonical bool) bool {
+					s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGTACGTACGTACGTN"))
+					got, err := kmer.Minimizers(immutable.New(s), w, k, canonical, f)
+					return err == nil && reflect.DeepEqual(got, naiveMinimizers(s, w, k, canonical, f))
+				},
+				gen.UIntRange(0, 300),
+				gen.UIntRange(1, 20),
+				gen.UIntRange(1, 21),
+				gen.Bool(),
+			),
+		)
+	}
+	properties.Property("Canonical minimizers do not depend on strand",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGT"))
+				kmers := func(seq string) []kmer.Kmer {
+					mins, _ := kmer.Minimizers(immutable.New(seq), 10, 15, true, kmer.Mix)
+					xs := make([]kmer.Kmer, len(mins))
+					for i, m := range mins {
+						xs[i] = m.Kmer
+					}
+					sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+					return xs
+				}
+				return reflect.DeepEqual(kmers(s), kmers(test.RevComp(s)))
+			},
+			gen.UIntRange(30, 300),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestSyncmers(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Open syncmers match decoding every s-mer",
+		prop.ForAll(
+			func(n, k, sub, offset uint) bool {
+				if sub >= k {
+					sub = k - 1
+				}
+				t := offset % (k - sub + 1)
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGTACGTACGTN"))
+				got, err := kmer.OpenSyncmers(immutable.New(s), k, sub, t, false, kmer.Mix)
+				want := naiveSyncmers(s, k, sub, kmer.Mix, func(at uint) bool { return at == t })
+				return err == nil && reflect.DeepEqual(got, want)
+			},
+			gen.UIntRange(0, 300),
+			gen.UIntRange(2, 25),
+			gen.UIntRange(1, 12),
+			gen.UIntRange(0, 25),
+		),
+	)
+	properties.Property("Closed syncmers match decoding every s-mer",
+		prop.ForAll(
+			func(n, k, sub uint) bool {
+				if sub >= k {
+					sub = k - 1
+				}
+				s := test.RandomStringFromRunes(test.Seed+int64(n), n, []rune("ACGTACGTACGTN"))
+				got, err := kmer.ClosedSyncmers(immutable.New(s), k, sub, false, kmer.Identity)
+				want := naiveSyncmers(s, k, sub, kmer.Identity, func(at uint) bool { return at == 0 || at == k-sub })
+				return err == nil && reflect.DeepEqual(got, want)
+			},
+			gen.UIntRange(0, 300),
+			gen.UIntRange(2, 25),
+			gen.UIntRange(1, 12),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestMix(t *testing.T) {
+	seen := make(map[uint64]bool)
+	for x := kmer.Kmer(0); x < 1<<12; x++ {
+		h := kmer.Mix(x, 6)
+		if h >= 1<<12 || seen[h] {
+			t.Fatalf("Mix should be invertible on 6-mers, Got: %d for %s", h, kmer.Decode(x, 6))
+		}
+		seen[h] = true
+	}
+}
+
+func TestMinimizerErrors(t *testing.T) {
+	s := immutable.New(strings.Repeat("ACGT", 10))
+	if _, err := kmer.Minimizers(s, 0, 5, true, kmer.Mix); err == nil {
+		t.Error("Want: error for a window of 0")
+	}
+	if _, err := kmer.Minimizers(s, 5, 0, true, kmer.Mix); err == nil {
+		t.Error("Want: error for k of 0")
+	}
+	if _, err := kmer.OpenSyncmers(s, 5, 5, 0, true, kmer.Mix); err == nil {
+		t.Error("Want: error for s-mers as long as k-mers")
+	}
+	if _, err := kmer.OpenSyncmers(s, 5, 2, 4, true, kmer.Mix); err == nil {
+		t.Error("Want: error for an offset past the last s-mer")
+	}
+	if _, err := kmer.ClosedSyncmers(s, 5, 0, true, kmer.Mix); err == nil {
+		t.Error("Want: error for s-mers of 0")
+	}
+}
+
+func ExampleMinimizers() {
+	mins, _ := kmer.Minimizers(immutable.New("GATTACAGATTACA"), 4, 3, false, kmer.Identity)
+	for _, m := range mins {
+		fmt.Println(m.Position, kmer.Decode(m.Kmer, 3))
+	}
+	// Output:
+	// 1 ATT
+	// 4 ACA
+	// 6 AGA
+	// 8 ATT
+	// 11 ACA
+}
+
+func BenchmarkMinimizers(b *testing.B) {
+	s := immutable.New(test.RandomStringFromRunes(test.Seed, 100000, []rune("ACGT")))
+	for i := 0; i < b.N; i++ {
+		kmer.Minimizers(s, 10, 15, true, kmer.Mix)
+	}
+}
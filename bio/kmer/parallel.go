@@ -0,0 +1,303 @@
+package kmer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/io/fastq"
+)
+
+// batchSize is how many k-mers a worker gathers for a shard before adding them
+const batchSize = 1024
+
+// ParallelCounter counts k-mers on many goroutines, sharding k-mers by hash
+// into tables each guarded by its own lock. Shards holding more k-mers than their
+// share of the memory bound are spilled to disk and merged back when read.
+type ParallelCounter struct {
+	k         uint
+	canonical bool
+	workers   uint
+	maxKmers  uint
+	dir       string
+	nShards   uint
+
+	shards []shard
+	total  uint
+	mu     sync.Mutex
+}
+
+// shard is one partition of the k-mers with any counts spilled to disk
+type shard struct {
+	mu     sync.Mutex
+	counts map[Kmer]uint
+	spills []string
+	err    error
+}
+
+// ParallelOption is a setting of a ParallelCounter
+type ParallelOption func(*ParallelCounter)
+
+// WorkersIs sets how many goroutines count k-mers (the number of CPUs by default)
+func WorkersIs(n uint) ParallelOption {
+	return func(p *ParallelCounter) {
+		p.workers = n
+	}
+}
+
+// ShardsIs sets how many partitions k-mers are split into (64 by default)
+func ShardsIs(n uint) ParallelOption {
+	return func(p *ParallelCounter) {
+		p.nShards = n
+	}
+}
+
+// MaxKmersIs bounds how many distinct k-mers are held in memory while counting,
+// spilling shards over their share to disk (unbounded if 0, the default)
+func MaxKmersIs(n uint) ParallelOption {
+	return func(p *ParallelCounter) {
+		p.maxKmers = n
+	}
+}
+
+// SpillDirIs sets the directory spilled shards are written to (os.TempDir by default)
+func SpillDirIs(dir string) ParallelOption {
+	return func(p *ParallelCounter) {
+		p.dir = dir
+	}
+}
+
+// NewParallelCounter generates a ParallelCounter of k-mers of k bases, which are
+// counted in their canonical form if canonical
+func NewParallelCounter(k uint, canonical bool, opts ...ParallelOption) (*ParallelCounter, error) {
+	if err := checkK(k); err != nil {
+		return nil, err
+	}
+	p := &ParallelCounter{
+		k:         k,
+		canonical: canonical,
+		workers:   uint(runtime.NumCPU()),
+		dir:       os.TempDir(),
+		nShards:   64,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.workers == 0 {
+		return nil, fmt.Errorf("k-mers must be counted by at least one worker")
+	}
+	if p.nShards == 0 {
+		return nil, fmt.Errorf("k-mers must be split into at least one shard")
+	}
+	p.shards = make([]shard, p.nShards)
+	for i := range p.shards {
+		p.shards[i].counts = make(map[Kmer]uint)
+	}
+	return p, nil
+}
+
+// shardOf is the shard k-mer x belongs to
+func (p *ParallelCounter) shardOf(x Kmer) int {
+	h := uint64(x) * 0x9E3779B97F4A7C15
+	return int((h >> 32) % uint64(len(p.shards)))
+}
+
+// Add counts the k-mers of every record received until records is closed,
+// returning the first error met adding to or spilling a shard
+func (p *ParallelCounter) Add(records <-chan fasta.Interface) error {
+	var wg sync.WaitGroup
+	for w := uint(0); w < p.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(records)
+		}()
+	}
+	wg.Wait()
+	for i := range p.shards {
+		if err := p.shards[i].err; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddScanner counts the k-mers of every record of a FASTQ Scanner
+func (p *ParallelCounter) AddScanner(s *fastq.Scanner) error {
+	records := make(chan fasta.Interface, p.workers*4)
+	go func() {
+		defer close(records)
+		for s.Scan() {
+			records <- s.Record()
+		}
+	}()
+	if err := p.Add(records); err != nil {
+		return err
+	}
+	return s.Err()
+}
+
+// work counts the k-mers of records in batches per shard
+func (p *ParallelCounter) work(records <-chan fasta.Interface) {
+	batches := make([][]Kmer, len(p.shards))
+	total := uint(0)
+	for r := range records {
+		it, _ := newIterator(r.Sequence(), p.k)
+		for it.Next() {
+			x := it.Kmer()
+			if p.canonical {
+				x = it.Canonical()
+			}
+			i := p.shardOf(x)
+			batches[i] = append(batches[i], x)
+			if len(batches[i]) == batchSize {
+				p.flush(i, batches[i])
+				batches[i] = batches[i][:0]
+			}
+			total++
+		}
+	}
+	for i, b := range batches {
+		if len(b) != 0 {
+			p.flush(i, b)
+		}
+	}
+	p.mu.Lock()
+	p.total += total
+	p.mu.Unlock()
+}
+
+// flush adds a batch of k-mers to shard i, spilling it if over its share of memory
+func (p *ParallelCounter) flush(i int, batch []Kmer) {
+	s := &p.shards[i]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, x := range batch {
+		s.counts[x]++
+	}
+	if p.maxKmers != 0 && uint(len(s.counts))*uint(len(p.shards)) > p.maxKmers && s.err == nil {
+		s.err = s.spill(p.dir)
+	}
+}
+
+// spill writes the counts of a shard to a file and empties it
+func (s *shard) spill(dir string) error {
+	f, err := ioutil.TempFile(dir, "kmers-*.bin")
+	if err != nil {
+		return err
+	}
+	s.spills = append(s.spills, f.Name())
+	w := bufio.NewWriter(f)
+	buf := make([]byte, 16)
+	for x, n := range s.counts {
+		binary.LittleEndian.PutUint64(buf[:8], uint64(x))
+		binary.LittleEndian.PutUint64(buf[8:], uint64(n))
+		if _, err := w.Write(buf); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	s.counts = make(map[Kmer]uint)
+	return f.Close()
+}
+
+// merged is all of the counts of a shard, in memory and spilled
+func (s *shard) merged() (map[Kmer]uint, error) {
+	if len(s.spills) == 0 {
+		return s.counts, nil
+	}
+	counts := make(map[Kmer]uint, len(s.counts))
+	for x, n := range s.counts {
+		counts[x] = n
+	}
+	buf := make([]byte, 16)
+	for _, name := range s.spills {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		r := bufio.NewReader(f)
+		for {
+			if _, err = io.ReadFull(r, buf); err != nil {
+				break
+			}
+			counts[Kmer(binary.LittleEndian.Uint64(buf[:8]))] += uint(binary.LittleEndian.Uint64(buf[8:]))
+		}
+		f.Close()
+		if err != io.EOF {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// Each calls f with every distinct k-mer and its count, one shard at a time and in
+// ascending order within each shard, so the order does not depend on how counting
+// was scheduled. It stops at the first error returned by f.
+func (p *ParallelCounter) Each(f func(x Kmer, n uint) error) error {
+	for i := range p.shards {
+		counts, err := p.shards[i].merged()
+		if err != nil {
+			return err
+		}
+		xs := make([]Kmer, 0, len(counts))
+		for x := range counts {
+			xs = append(xs, x)
+		}
+		sort.Slice(xs, func(i, j int) bool {
+			return xs[i] < xs[j]
+		})
+		for _, x := range xs {
+			if err := f(x, counts[x]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Counter merges the counts of every shard into a Counter
+func (p *ParallelCounter) Counter() (*Counter, error) {
+	c, _ := NewCounter(p.k, p.canonical)
+	c.total = p.total
+	err := p.Each(func(x Kmer, n uint) error {
+		c.counts[x] = n
+		return nil
+	})
+	return c, err
+}
+
+// Spectrum is the k-mer spectrum of the counts, computed a shard at a time
+func (p *ParallelCounter) Spectrum() ([]Bin, error) {
+	hist := make(map[uint]uint)
+	err := p.Each(func(x Kmer, n uint) error {
+		hist[n]++
+		return nil
+	})
+	return bins(hist), err
+}
+
+// Close removes any spilled shards, whose counts are then lost
+func (p *ParallelCounter) Close() error {
+	var err error
+	for i := range p.shards {
+		for _, name := range p.shards[i].spills {
+			if e := os.Remove(name); e != nil && err == nil {
+				err = e
+			}
+		}
+		p.shards[i].spills = nil
+	}
+	return err
+}
@@ -0,0 +1,185 @@
+package kmer_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/io/fasta/base"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// records generates n random records
+func records(seed int64, n int) []fasta.Interface {
+	recs := make([]fasta.Interface, n)
+	for i := range recs {
+		s := test.RandomStringFromRunes(seed+int64(i), uint(50+i%50), []rune("ACGTACGTN"))
+		recs[i] = base.New(fmt.Sprint(i), immutable.New(s))
+	}
+	return recs
+}
+
+// send streams records on a channel
+func send(recs []fasta.Interface) <-chan fasta.Interface {
+	ch := make(chan fasta.Interface)
+	go func() {
+		defer close(ch)
+		for _, r := range recs {
+			ch <- r
+		}
+	}()
+	return ch
+}
+
+func TestParallelCounter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kmer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	parameters.MinSuccessfulTests = 25
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Parallel counts match counting on one goroutine",
+		prop.ForAll(
+			func(n int, workers, shards, maxKmers uint, canonical bool) bool {
+				recs := records(int64(n), n)
+				want, _ := kmer.NewCounter(11, canonical)
+				for _, r := range recs {
+					want.AddRecord(r)
+				}
+
+				p, err := kmer.NewParallelCounter(11, canonical,
+					kmer.WorkersIs(workers),
+					kmer.ShardsIs(shards),
+					kmer.MaxKmersIs(maxKmers),
+					kmer.SpillDirIs(dir),
+				)
+				if err != nil {
+					return false
+				}
+				defer p.Close()
+				if err := p.Add(send(recs)); err != nil {
+					return false
+				}
+				got, err := p.Counter()
+				if err != nil {
+					return false
+				}
+				spectrum, err := p.Spectrum()
+				return err == nil &&
+					reflect.DeepEqual(got, want) &&
+					reflect.DeepEqual(spectrum, want.Spectrum())
+			},
+			gen.IntRange(0, 200),
+			gen.UIntRange(1, 8),
+			gen.UIntRange(1, 16),
+			gen.UIntRange(0, 500),
+			gen.Bool(),
+		),
+	)
+	properties.TestingRun(t)
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 0 {
+		t.Errorf("Want: spilled shards removed, Got: %d files", len(files))
+	}
+}
+
+func TestParallelCounterEach(t *testing.T) {
+	recs := records(test.Seed, 100)
+	order := func(workers uint) []kmer.Kmer {
+		p, _ := kmer.NewParallelCounter(5, true, kmer.WorkersIs(workers), kmer.MaxKmersIs(100))
+		defer p.Close()
+		p.Add(send(recs))
+		xs := make([]kmer.Kmer, 0)
+		p.Each(func(x kmer.Kmer, n uint) error {
+			xs = append(xs, x)
+			return nil
+		})
+		return xs
+	}
+	if a, b := order(1), order(8); len(a) == 0 || !reflect.DeepEqual(a, b) {
+		t.Error("k-mers should be visited in the same order however they were counted")
+	}
+	stop := fmt.Errorf("stop")
+	p, _ := kmer.NewParallelCounter(5, true)
+	p.Add(send(recs))
+	if err := p.Each(func(x kmer.Kmer, n uint) error { return stop }); err != stop {
+		t.Errorf("Want: %v, Got: %v", stop, err)
+	}
+}
+
+func TestParallelCounterScanner(t *testing.T) {
+	in := fastq.TestGenMultiFastq(test.Seed, 200, 10, hashmap.NewDna())
+	gen := func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	}
+	recs, err := fastq.ReadMulti(bytes.NewReader(in), gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := kmer.NewCounter(21, true)
+	for _, r := range recs {
+		want.AddRecord(r)
+	}
+	p, _ := kmer.NewParallelCounter(21, true)
+	if err := p.AddScanner(fastq.NewScanner(bytes.NewReader(in), gen)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := p.Counter(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %d distinct, Got: %d %v", want.Distinct(), got.Distinct(), err)
+	}
+	if err := p.AddScanner(fastq.NewScanner(bytes.NewReader([]byte("@r\nACGU\n+\nIIII\n")), gen)); err == nil {
+		t.Error("Want: the scanner error")
+	}
+	if _, err := kmer.NewParallelCounter(33, true); err == nil {
+		t.Error("Want: error for k of 33")
+	}
+	if _, err := kmer.NewParallelCounter(21, true, kmer.ShardsIs(0)); err == nil {
+		t.Error("Want: error for no shards")
+	}
+	if _, err := kmer.NewParallelCounter(21, true, kmer.WorkersIs(0)); err == nil {
+		t.Error("Want: error for no workers")
+	}
+}
+
+func ExampleParallelCounter() {
+	p, _ := kmer.NewParallelCounter(3, true, kmer.WorkersIs(4))
+	defer p.Close()
+
+	records := make(chan fasta.Interface)
+	go func() {
+		defer close(records)
+		records <- base.New("r1", immutable.New("ACGTACG"))
+		records <- base.New("r2", immutable.New("CGTACGT"))
+	}()
+	p.Add(records)
+
+	c, _ := p.Counter()
+	fmt.Println(c.Distinct(), c.Total())
+	// Output: 2 10
+}
+
+func BenchmarkParallelCounter(b *testing.B) {
+	recs := records(test.Seed, 1000)
+	for i := 0; i < b.N; i++ {
+		p, _ := kmer.NewParallelCounter(21, true)
+		p.Add(send(recs))
+		p.Close()
+	}
+}
@@ -0,0 +1,236 @@
+package ahocorasick
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// Pattern is a named sequence to search for
+type Pattern struct {
+	Name     string
+	Sequence string
+}
+
+// Match is a match of a pattern in a sequence. Start and End are positions along the
+// sequence as given, whichever strand matched.
+type Match struct {
+	Pattern int // which pattern matched, in the order given to New
+	Start   int
+	End     int
+	Strand  byte // '+' if the pattern matched the sequence as given, '-' if its reverse complement did
+}
+
+// output is a pattern, on one strand, ending at a state of the automaton
+type output struct {
+	pattern int
+	length  int
+	strand  byte
+}
+
+// Automaton is an Aho-Corasick automaton matching a set of patterns
+type Automaton struct {
+	patterns    []Pattern
+	bothStrands bool
+	codes       [256]int32 // the column of each letter in next, with 0 for letters in no pattern
+	sigma       int32
+	next        []int32 // the state reached from each state on each letter
+	outputs     [][]output
+	dict        []int32 // the nearest state on the failure path with outputs, or -1
+}
+
+// Option is a setting of an Automaton
+type Option func(*Automaton)
+
+// BothStrands also matches the reverse complement of every pattern, which must be DNA
+// written with IUPAC letters. Patterns that are their own reverse complement are only
+// matched on the '+' strand.
+func BothStrands() Option {
+	return func(a *Automaton) {
+		a.bothStrands = true
+	}
+}
+
+// New generates an Automaton matching patterns. Letters are matched exactly, so patterns
+// must match the case of the sequences searched.
+func New(patterns []Pattern, opts ...Option) (*Automaton, error) {
+	a := &Automaton{patterns: patterns, sigma: 1}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("an automaton needs at least one pattern")
+	}
+
+	type entry struct {
+		seq string
+		output
+	}
+	var entries []entry
+	for i, p := range patterns {
+		if p.Sequence == "" {
+			return nil, fmt.Errorf("pattern %q is empty", p.Name)
+		}
+		entries = append(entries, entry{p.Sequence, output{i, len(p.Sequence), '+'}})
+		if !a.bothStrands {
+			continue
+		}
+		rc, err := revComp(p.Sequence)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %v", p.Name, err)
+		}
+		if rc != p.Sequence {
+			entries = append(entries, entry{rc, output{i, len(rc), '-'}})
+		}
+	}
+	for _, e := range entries {
+		for i := 0; i < len(e.seq); i++ {
+			if a.codes[e.seq[i]] == 0 {
+				a.codes[e.seq[i]] = a.sigma
+				a.sigma++
+			}
+		}
+	}
+
+	// build the trie of the patterns
+	a.addState()
+	for _, e := range entries {
+		state := int32(0)
+		for i := 0; i < len(e.seq); i++ {
+			c := a.codes[e.seq[i]]
+			if a.next[state*a.sigma+c] <= 0 {
+				a.next[state*a.sigma+c] = a.addState()
+			}
+			state = a.next[state*a.sigma+c]
+		}
+		a.outputs[state] = append(a.outputs[state], e.output)
+	}
+
+	// complete the transitions breadth first, following failure links
+	fail := make([]int32, len(a.outputs))
+	queue := []int32{}
+	for c := int32(0); c < a.sigma; c++ {
+		if s := a.next[c]; s > 0 {
+			queue = append(queue, s)
+		} else {
+			a.next[c] = 0
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		if f := fail[state]; len(a.outputs[f]) > 0 {
+			a.dict[state] = f
+		} else {
+			a.dict[state] = a.dict[f]
+		}
+		for c := int32(0); c < a.sigma; c++ {
+			s := a.next[state*a.sigma+c]
+			through := a.next[fail[state]*a.sigma+c]
+			if s > 0 {
+				fail[s] = through
+				queue = append(queue, s)
+			} else {
+				a.next[state*a.sigma+c] = through
+			}
+		}
+	}
+	return a, nil
+}
+
+// addState adds a state with no transitions to the automaton
+func (a *Automaton) addState() int32 {
+	for c := int32(0); c < a.sigma; c++ {
+		a.next = append(a.next, -1)
+	}
+	a.outputs = append(a.outputs, nil)
+	a.dict = append(a.dict, -1)
+	return int32(len(a.outputs) - 1)
+}
+
+// revComp is the reverse complement of IUPAC DNA
+func revComp(s string) (string, error) {
+	x := hashmap.NewDnaIupac()
+	rc := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		c := x.Complement(string(s[i]))
+		if c == "X" {
+			return "", fmt.Errorf("%q is not an IUPAC nucleotide", s[i])
+		}
+		rc[len(s)-1-i] = c[0]
+	}
+	return string(rc), nil
+}
+
+// Pattern is the pattern with index i
+func (a *Automaton) Pattern(i int) Pattern {
+	return a.patterns[i]
+}
+
+// Find finds every match of the patterns in s
+func (a *Automaton) Find(s sequence.Interface) ([]Match, error) {
+	seq, err := s.Range(0, s.Length())
+	if err != nil {
+		return nil, err
+	}
+	return a.FindString(seq), nil
+}
+
+// FindRecord finds every match of the patterns in the sequence of a FASTA or FASTQ record
+func (a *Automaton) FindRecord(r fasta.Interface) []Match {
+	return a.FindString(r.Sequence())
+}
+
+// FindString finds every match of the patterns in seq, including overlapping matches,
+// in order of position, then pattern, then strand
+func (a *Automaton) FindString(seq string) []Match {
+	var matches []Match
+	state := int32(0)
+	for i := 0; i < len(seq); i++ {
+		state = a.next[state*a.sigma+a.codes[seq[i]]]
+		s := state
+		if len(a.outputs[s]) == 0 {
+			s = a.dict[s]
+		}
+		for ; s > 0; s = a.dict[s] {
+			for _, o := range a.outputs[s] {
+				matches = append(matches, Match{o.pattern, i + 1 - o.length, i + 1, o.strand})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		x, y := matches[i], matches[j]
+		if x.Start != y.Start {
+			return x.Start < y.Start
+		}
+		if x.End != y.End {
+			return x.End < y.End
+		}
+		if x.Pattern != y.Pattern {
+			return x.Pattern < y.Pattern
+		}
+		return x.Strand < y.Strand
+	})
+	return matches
+}
+
+// Screen finds the matches in each record received until records is closed, passing
+// them to f with the record. It can be called from several goroutines at once.
+func (a *Automaton) Screen(records <-chan fasta.Interface, f func(fasta.Interface, []Match)) {
+	for r := range records {
+		f(r, a.FindRecord(r))
+	}
+}
+
+// ScreenScanner finds the matches in each record of a FASTQ scanner, passing them to f
+// with the record
+func (a *Automaton) ScreenScanner(s *fastq.Scanner, f func(fastq.Interface, []Match)) error {
+	for s.Scan() {
+		f(s.Record(), a.FindRecord(s.Record()))
+	}
+	return s.Err()
+}
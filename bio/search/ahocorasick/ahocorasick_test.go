@@ -0,0 +1,192 @@
+package ahocorasick_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/io/fasta/base"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/search/ahocorasick"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// patterns names sequences by their place
+func patterns(seqs []string) []ahocorasick.Pattern {
+	ps := make([]ahocorasick.Pattern, len(seqs))
+	for i, s := range seqs {
+		ps[i] = ahocorasick.Pattern{Name: fmt.Sprint("p", i), Sequence: s}
+	}
+	return ps
+}
+
+// naive finds every match of every pattern by searching for each in turn
+func naive(seqs []string, seq string, bothStrands bool) []ahocorasick.Match {
+	var matches []ahocorasick.Match
+	for i, p := range seqs {
+		for j := 0; j+len(p) <= len(seq); j++ {
+			if strings.HasPrefix(seq[j:], p) {
+				matches = append(matches, ahocorasick.Match{Pattern: i, Start: j, End: j + len(p), Strand: '+'})
+			}
+			if rc := test.RevComp(p); bothStrands && rc != p && strings.HasPrefix(seq[j:], rc) {
+				matches = append(matches, ahocorasick.Match{Pattern: i, Start: j, End: j + len(p), Strand: '-'})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		x, y := matches[i], matches[j]
+		if x.Start != y.Start {
+			return x.Start < y.Start
+		}
+		if x.End != y.End {
+			return x.End < y.End
+		}
+		if x.Pattern != y.Pattern {
+			return x.Pattern < y.Pattern
+		}
+		return x.Strand < y.Strand
+	})
+	return matches
+}
+
+func TestFindString(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("FindString finds what searching for each pattern finds",
+		prop.ForAll(
+			func(seqs []string, seq string, bothStrands bool) bool {
+				var opts []ahocorasick.Option
+				if bothStrands {
+					opts = append(opts, ahocorasick.BothStrands())
+				}
+				a, err := ahocorasick.New(patterns(seqs), opts...)
+				if err != nil {
+					return false
+				}
+				return reflect.DeepEqual(a.FindString(seq), naive(seqs, seq, bothStrands))
+			},
+			gen.SliceOfN(20, gen.RegexMatch("[ACGT]{1,6}")),
+			gen.RegexMatch("[ACGTN]{0,300}"),
+			gen.Bool(),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestFind(t *testing.T) {
+	a, _ := ahocorasick.New(patterns([]string{"he", "she", "his", "hers"}))
+	got, err := a.Find(immutable.New("ushers"))
+	want := []ahocorasick.Match{{1, 1, 4, '+'}, {0, 2, 4, '+'}, {3, 2, 6, '+'}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, got, err)
+	}
+	if got := a.FindString("USHERS"); len(got) != 0 {
+		t.Errorf("Want: letters matched exactly, Got: %v", got)
+	}
+	if a.Pattern(3).Name != "p3" {
+		t.Errorf("Want: p3, Got: %v", a.Pattern(3))
+	}
+}
+
+func TestScreen(t *testing.T) {
+	a, _ := ahocorasick.New(patterns([]string{"AGATCGGAAGAGC", "CTGTCTCTTATA"}), ahocorasick.BothStrands())
+	reads := []string{
+		"TTTTAGATCGGAAGAGCTTTT",
+		"GGGGGGGGGGGGGGGGG",
+		"TATAAGAGACAGCCCC",
+	}
+	records := make(chan fasta.Interface)
+	go func() {
+		defer close(records)
+		for i, r := range reads {
+			records <- base.New(fmt.Sprint("r", i), immutable.New(r))
+		}
+	}()
+	var mu sync.Mutex
+	found := map[string][]ahocorasick.Match{}
+	var wg sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.Screen(records, func(r fasta.Interface, ms []ahocorasick.Match) {
+				mu.Lock()
+				found[r.Header()] = ms
+				mu.Unlock()
+			})
+		}()
+	}
+	wg.Wait()
+	want := map[string][]ahocorasick.Match{
+		"r0": {{0, 4, 17, '+'}},
+		"r1": nil,
+		"r2": {{1, 0, 12, '-'}},
+	}
+	if !reflect.DeepEqual(found, want) {
+		t.Errorf("Want: %v, Got: %v", want, found)
+	}
+}
+
+func TestScreenScanner(t *testing.T) {
+	in := "@r0\nACGTACGTAC\n+\nIIIIIIIIII\n@r1\nTTTTTTTTTT\n+\nIIIIIIIIII\n"
+	a, _ := ahocorasick.New(patterns([]string{"GTAC"}))
+	s := fastq.NewScanner(strings.NewReader(in), func(s string) (sequence.Interface, error) {
+		return immutable.NewDna(s)
+	})
+	counts := map[string]int{}
+	err := a.ScreenScanner(s, func(r fastq.Interface, ms []ahocorasick.Match) {
+		counts[r.Header()] = len(ms)
+	})
+	if want := map[string]int{"r0": 2, "r1": 0}; err != nil || !reflect.DeepEqual(counts, want) {
+		t.Errorf("Want: %v, Got: %v %v", want, counts, err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := ahocorasick.New(nil); err == nil {
+		t.Error("Want: error for no patterns")
+	}
+	if _, err := ahocorasick.New(patterns([]string{"ACGT", ""})); err == nil {
+		t.Error("Want: error for an empty pattern")
+	}
+	if _, err := ahocorasick.New(patterns([]string{"ACGX"}), ahocorasick.BothStrands()); err == nil {
+		t.Error("Want: error for reverse complementing a letter that is not DNA")
+	}
+}
+
+func ExampleAutomaton_FindString() {
+	a, _ := ahocorasick.New([]ahocorasick.Pattern{
+		{Name: "BC01", Sequence: "AAGAAAGTTGTCGGTGTCTTTGTG"},
+		{Name: "BC02", Sequence: "TCGATTCCGTTTGTAGTCGTCTGT"},
+	}, ahocorasick.BothStrands())
+	read := "GGACAGACGACTACAAACGGAATCGATTTT" + "AAGAAAGTTGTCGGTGTCTTTGTGCC"
+	for _, m := range a.FindString(read) {
+		fmt.Println(a.Pattern(m.Pattern).Name, m.Start, m.End, string(m.Strand))
+	}
+	// Output:
+	// BC02 2 26 -
+	// BC01 30 54 +
+}
+
+func BenchmarkFindString(b *testing.B) {
+	var seqs []string
+	for i := 0; i < 1000; i++ {
+		seqs = append(seqs, test.RandomStringFromRunes(test.Seed+int64(i), 12, []rune("ACGT")))
+	}
+	a, _ := ahocorasick.New(patterns(seqs), ahocorasick.BothStrands())
+	read := test.RandomStringFromRunes(test.Seed, 150, []rune("ACGT"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.FindString(read)
+	}
+}
@@ -0,0 +1,9 @@
+/*
+Package ahocorasick finds many patterns in sequences at once with an Aho-Corasick automaton.
+
+An Automaton is built once from a set of named patterns, such as barcodes, adapters or
+vector sequences, and then scans each sequence in a single pass whatever the number of
+patterns, reporting every match including overlapping ones. Patterns can be matched on
+both strands by adding their reverse complements automatically.
+*/
+package ahocorasick
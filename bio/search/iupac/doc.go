@@ -0,0 +1,9 @@
+/*
+Package iupac searches DNA sequences for patterns written with IUPAC ambiguity codes,
+such as the restriction site GANTC or the motif RGGNCCY, on both strands.
+
+A pattern letter matches a sequence letter when the nucleotides they stand for overlap,
+so ambiguity codes in either the pattern or the sequence match compatible bases: R matches
+A, G, R, and N but not C. The gap matches nothing.
+*/
+package iupac
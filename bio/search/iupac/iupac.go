@@ -0,0 +1,123 @@
+package iupac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// MaxLength is the longest pattern that can be searched for
+const MaxLength = 64
+
+// bits are the nucleotides each letter stands for, one bit per nucleotide, in either case
+var bits = func() [256]byte {
+	var b [256]byte
+	a := hashmap.NewDnaIupac()
+	for _, c := range "ACGTRYSWKMBDHVN" {
+		for _, n := range a.Expand(string(c)) {
+			b[c] |= 1 << uint(strings.IndexRune("ACGT", n))
+		}
+		b[c|0x20] = b[c]
+	}
+	return b
+}()
+
+// Pattern is a DNA pattern which may hold IUPAC ambiguity codes
+type Pattern struct {
+	pattern    string
+	forward    [256]uint64
+	reverse    [256]uint64
+	palindrome bool
+}
+
+// Match is a match of a Pattern in a sequence. Start and End are positions along the
+// sequence as given, whichever strand matched.
+type Match struct {
+	Start  int
+	End    int
+	Strand byte // '+' if the pattern matched the sequence as given, '-' if its reverse complement did
+}
+
+// New generates a Pattern from IUPAC DNA letters, of up to MaxLength letters
+func New(pattern string) (*Pattern, error) {
+	pattern = strings.ToUpper(pattern)
+	if len(pattern) == 0 || len(pattern) > MaxLength {
+		return nil, fmt.Errorf("pattern %q must have from 1 to %d letters", pattern, MaxLength)
+	}
+	a := hashmap.NewDnaIupac()
+	rc := make([]byte, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if bits[c] == 0 {
+			return nil, fmt.Errorf("pattern %q has %q which is not an IUPAC nucleotide", pattern, c)
+		}
+		rc[len(pattern)-1-i] = a.Complement(string(c))[0]
+	}
+	return &Pattern{
+		pattern:    pattern,
+		forward:    masks(pattern),
+		reverse:    masks(string(rc)),
+		palindrome: string(rc) == pattern,
+	}, nil
+}
+
+// masks are, for each letter, the positions of the pattern it is compatible with
+func masks(pattern string) [256]uint64 {
+	var m [256]uint64
+	for c := range m {
+		for i := 0; i < len(pattern); i++ {
+			if bits[c]&bits[pattern[i]] != 0 {
+				m[c] |= 1 << uint(i)
+			}
+		}
+	}
+	return m
+}
+
+// String is the pattern in upper case
+func (p *Pattern) String() string {
+	return p.pattern
+}
+
+// Palindromic is whether the pattern is its own reverse complement, as many restriction
+// sites are. Palindromic patterns are only matched on the '+' strand.
+func (p *Pattern) Palindromic() bool {
+	return p.palindrome
+}
+
+// Find finds every match of the pattern on both strands of s, including overlapping
+// matches, in order of position then strand
+func (p *Pattern) Find(s sequence.Interface) ([]Match, error) {
+	seq, err := s.Range(0, s.Length())
+	if err != nil {
+		return nil, err
+	}
+	return p.FindString(seq), nil
+}
+
+// FindString finds every match of the pattern on both strands of seq
+func (p *Pattern) FindString(seq string) []Match {
+	matches := find(seq, len(p.pattern), &p.forward, '+', nil)
+	if !p.palindrome {
+		matches = find(seq, len(p.pattern), &p.reverse, '-', matches)
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	}
+	return matches
+}
+
+// find appends the matches of a pattern of length m with masks to matches using the
+// shift-and algorithm
+func find(seq string, m int, masks *[256]uint64, strand byte, matches []Match) []Match {
+	found := uint64(1) << uint(m-1)
+	state := uint64(0)
+	for i := 0; i < len(seq); i++ {
+		state = (state<<1 | 1) & masks[seq[i]]
+		if state&found != 0 {
+			matches = append(matches, Match{i + 1 - m, i + 1, strand})
+		}
+	}
+	return matches
+}
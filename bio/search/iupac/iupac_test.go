@@ -0,0 +1,129 @@
+package iupac_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/search/iupac"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// compatible is whether IUPAC letters a and b share a nucleotide
+func compatible(a, b byte) bool {
+	x := hashmap.NewDnaIupac()
+	return strings.ContainsAny(x.Expand(strings.ToUpper(string(a))), x.Expand(strings.ToUpper(string(b))))
+}
+
+// naive finds matches of pattern by comparing it at every position
+func naive(pattern, seq string) []iupac.Match {
+	matches := []iupac.Match{}
+	strands := []struct {
+		p      string
+		strand byte
+	}{{pattern, '+'}, {test.RevComp(pattern), '-'}}
+	if test.RevComp(pattern) == pattern {
+		strands = strands[:1]
+	}
+	for i := 0; i+len(pattern) <= len(seq); i++ {
+		for _, s := range strands {
+			ok := true
+			for j := range s.p {
+				ok = ok && compatible(s.p[j], seq[i+j])
+			}
+			if ok {
+				matches = append(matches, iupac.Match{Start: i, End: i + len(pattern), Strand: s.strand})
+			}
+		}
+	}
+	return matches
+}
+
+func TestFind(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Find matches compatible letters on both strands",
+		prop.ForAll(
+			func(pattern, seq string) bool {
+				p, err := iupac.New(pattern)
+				if err != nil {
+					return false
+				}
+				got := p.FindString(seq)
+				if got == nil {
+					got = []iupac.Match{}
+				}
+				return reflect.DeepEqual(got, naive(pattern, seq))
+			},
+			gen.RegexMatch("[ACGTN]{0,3}[ACGTRYSWKMBDHVN]{1,3}"),
+			gen.RegexMatch("[ACGTacgt]{0,100}[ACGTRYSWKMBDHVN-]{0,20}[ACGT]{0,50}"),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestFindSequence(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, seq string
+		want         []iupac.Match
+	}{
+		{"GANTC", "AAGATTCAGACTCA", []iupac.Match{{2, 7, '+'}, {8, 13, '+'}}},
+		{"RGGNCCY", "GGGACCTNNNNNNNAGGTCCC", []iupac.Match{{0, 7, '+'}, {7, 14, '+'}, {14, 21, '+'}}},
+		{"CAAT", "ATTGCAAT", []iupac.Match{{0, 4, '-'}, {4, 8, '+'}}},
+		{"GAATTC", "GAATTC-GAAWTC", []iupac.Match{{0, 6, '+'}, {7, 13, '+'}}},
+	} {
+		p, _ := iupac.New(tc.pattern)
+		seq, err := immutable.NewDnaIupac(tc.seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := p.Find(seq); err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s in %s Want: %v, Got: %v %v", tc.pattern, tc.seq, tc.want, got, err)
+		}
+	}
+}
+
+func TestPalindromic(t *testing.T) {
+	for pattern, want := range map[string]bool{
+		"GAATTC":  true,
+		"GANTC":   true,
+		"RGGNCCY": true,
+		"GGATG":   false,
+		"A":       false,
+		"N":       true,
+	} {
+		if p, _ := iupac.New(pattern); p.Palindromic() != want {
+			t.Errorf("%s Want: %v, Got: %v", pattern, want, p.Palindromic())
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, pattern := range []string{"", "GAXTC", "GA-TC", strings.Repeat("A", iupac.MaxLength+1)} {
+		if _, err := iupac.New(pattern); err == nil {
+			t.Errorf("%q Want: error", pattern)
+		}
+	}
+	if p, err := iupac.New("ganTC"); err != nil || p.String() != "GANTC" {
+		t.Errorf("Want: GANTC, Got: %v %v", p, err)
+	}
+}
+
+func ExamplePattern_Find() {
+	p, _ := iupac.New("GGATG") // FokI
+	seq, _ := immutable.NewDna("TTGGATGCCCATCCAA")
+	matches, _ := p.Find(seq)
+	for _, m := range matches {
+		fmt.Println(m.Start, m.End, string(m.Strand))
+	}
+	// Output:
+	// 2 7 +
+	// 9 14 -
+}
@@ -0,0 +1,114 @@
+package circular
+
+import (
+	"fmt"
+
+	"github.com/sembio/go/bio/sequence"
+)
+
+var _ sequence.Interface = new(Circular)
+var _ sequence.RevComper = new(Circular)
+
+// Circular is a sequence whose end joins its start
+type Circular struct {
+	seq    sequence.Interface
+	text   string
+	origin uint
+}
+
+// New generates a Circular sequence from s, with its origin at the start of s
+func New(s sequence.Interface) (*Circular, error) {
+	text, err := s.Range(0, s.Length())
+	if err != nil {
+		return nil, err
+	}
+	return &Circular{seq: s, text: text}, nil
+}
+
+// Length is the number of positions in the sequence
+func (c *Circular) Length() uint {
+	return uint(len(c.text))
+}
+
+// Position is the letter found at position n from the origin
+func (c *Circular) Position(n uint) (string, error) {
+	if n >= c.Length() {
+		return "", fmt.Errorf("requested impossible position [%d]", n)
+	}
+	return string(c.text[(c.origin+n)%c.Length()]), nil
+}
+
+// Range is the letters from start (inclusive) to stop (exclusive). Ranges with stop
+// before start wrap past the origin, and ranges with stop equal to start are empty
+// (see RangeFrom to read the whole sequence from start).
+func (c *Circular) Range(start, stop uint) (string, error) {
+	n := c.Length()
+	if start == stop && stop <= n {
+		return "", nil
+	}
+	if start >= n || stop > n {
+		return "", fmt.Errorf("requested impossible range [%d:%d]", start, stop)
+	}
+	return c.read(start, (stop+n-start-1)%n+1), nil
+}
+
+// RangeFrom is the whole sequence read from start, wrapping past the origin
+func (c *Circular) RangeFrom(start uint) (string, error) {
+	n := c.Length()
+	if n == 0 && start == 0 {
+		return "", nil
+	}
+	if start >= n {
+		return "", fmt.Errorf("requested impossible start [%d]", start)
+	}
+	return c.read(start, n), nil
+}
+
+// read is the length letters from start
+func (c *Circular) read(start, length uint) string {
+	n := c.Length()
+	from := (c.origin + start) % n
+	if from+length <= n {
+		return c.text[from : from+length]
+	}
+	return c.text[from:] + c.text[:from+length-n]
+}
+
+// String is the sequence read from its origin
+func (c *Circular) String() string {
+	s, _ := c.RangeFrom(0)
+	return s
+}
+
+// Rotate is the same sequence with its origin moved to position origin
+func (c *Circular) Rotate(origin uint) (*Circular, error) {
+	if origin > 0 && origin >= c.Length() {
+		return nil, fmt.Errorf("requested impossible origin [%d]", origin)
+	}
+	r := *c
+	if n := c.Length(); n > 0 {
+		r.origin = (c.origin + origin) % n
+	}
+	return &r, nil
+}
+
+// RevComp is the Circular reverse complement of the sequence, starting with the complement
+// of the last letter of c. The wrapped sequence must be able to reverse complement itself.
+func (c *Circular) RevComp() (sequence.Interface, error) {
+	rc, ok := c.seq.(sequence.RevComper)
+	if !ok {
+		return nil, fmt.Errorf("sequence cannot be reverse complemented")
+	}
+	s, err := rc.RevComp()
+	if err != nil {
+		return nil, err
+	}
+	r, err := New(s)
+	if err != nil {
+		return nil, err
+	}
+	if n := r.Length(); n > 0 {
+		r.origin = (n - c.origin) % n
+	}
+	return r, nil
+}
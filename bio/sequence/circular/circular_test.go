@@ -0,0 +1,101 @@
+package circular_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/sequence/circular"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/test"
+)
+
+// dna is s as a circular DNA sequence
+func dna(s string) *circular.Circular {
+	c, err := circular.New(immutable.TestDna(s))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestRange(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Range reads around the origin",
+		prop.ForAll(
+			func(s string, origin, start, stop uint) bool {
+				n := uint(len(s))
+				origin, start, stop = origin%n, start%n, stop%(n+1)
+				c, _ := dna(s).Rotate(origin)
+				rotated := s[origin:] + s[:origin]
+				got, err := c.Range(start, stop)
+				length := stop - start
+				if stop < start {
+					length = stop + n - start
+				}
+				want := (rotated + rotated)[start : start+length]
+				whole, errFrom := c.RangeFrom(start)
+				pos, _ := c.Position(start)
+				return err == nil && got == want && errFrom == nil && whole == (rotated + rotated)[start:start+n] &&
+					c.String() == rotated && pos == rotated[start:start+1]
+			},
+			gen.RegexMatch("[ACGT]{1,50}"),
+			gen.UInt(),
+			gen.UInt(),
+			gen.UInt(),
+		),
+	)
+	properties.Property("RevComp of a rotated sequence is its reversed complement",
+		prop.ForAll(
+			func(s string, origin uint) bool {
+				c, _ := dna(s).Rotate(origin % uint(len(s)))
+				rc, err := c.RevComp()
+				return err == nil && rc.(*circular.Circular).String() == test.RevComp(c.String())
+			},
+			gen.RegexMatch("[ACGT]{1,50}"),
+			gen.UInt(),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestRangeErrors(t *testing.T) {
+	c := dna("GATTACA")
+	for _, r := range [][2]uint{{7, 0}, {0, 8}, {9, 2}} {
+		if _, err := c.Range(r[0], r[1]); err == nil {
+			t.Errorf("%v Want: error", r)
+		}
+	}
+	if _, err := c.RangeFrom(7); err == nil {
+		t.Error("Want: error reading from past the end")
+	}
+	if s, err := c.Range(3, 3); s != "" || err != nil {
+		t.Errorf("Want: nothing from an empty range, Got: %q %v", s, err)
+	}
+	if s, err := c.Range(0, 7); s != "GATTACA" || err != nil {
+		t.Errorf("Want: the whole sequence, Got: %q %v", s, err)
+	}
+	if _, err := c.Position(7); err == nil {
+		t.Error("Want: error for a position past the end")
+	}
+	if _, err := c.Rotate(7); err == nil {
+		t.Error("Want: error for an origin past the end")
+	}
+	if _, err := circular.New(immutable.New("ABC")); err != nil {
+		t.Fatal(err)
+	}
+	protein, _ := circular.New(immutable.New("ABC"))
+	if _, err := protein.RevComp(); err == nil {
+		t.Error("Want: error reverse complementing a sequence that cannot")
+	}
+	empty := dna("")
+	if s, err := empty.Range(0, 0); s != "" || err != nil {
+		t.Errorf("Want: nothing from an empty sequence, Got: %q %v", s, err)
+	}
+	if s, err := empty.RangeFrom(0); s != "" || err != nil {
+		t.Errorf("Want: nothing from an empty sequence, Got: %q %v", s, err)
+	}
+}
@@ -0,0 +1,8 @@
+/*
+Package circular wraps sequences whose end joins their start, such as plasmids,
+mitochondrial genomes, and bacterial chromosomes.
+
+A Circular sequence reads ranges across its origin, can be rotated to a new origin, and
+finds patterns and open reading frames that cross the junction of its end and start.
+*/
+package circular
@@ -0,0 +1,121 @@
+package circular
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sembio/go/bio/data/codon"
+)
+
+// ORF is an open reading frame, from a start codon up to and including a stop codon.
+// Start and End are positions along the sequence from its origin, whichever strand the
+// ORF is on.
+type ORF struct {
+	Start  int
+	End    int // past Length for ORFs across the origin
+	Strand byte
+}
+
+// ORFs finds the open reading frames of at least minCodons codons, counting the stop,
+// on both strands using the start and stop codons of table. Only the longest ORF ending
+// at each stop codon is kept, and ORFs are never longer than the sequence, so frames
+// without a stop codon are not reported.
+func (c *Circular) ORFs(table codon.Interface, minCodons int) ([]ORF, error) {
+	rc, err := c.RevComp()
+	if err != nil {
+		return nil, err
+	}
+	n := int(c.Length())
+	found := orfs(strings.ToUpper(c.String()), table, minCodons, '+')
+	for _, o := range orfs(strings.ToUpper(rc.(*Circular).String()), table, minCodons, '-') {
+		start := mod(n-o.End, n)
+		found = append(found, ORF{start, start + o.End - o.Start, '-'})
+	}
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.Strand < b.Strand
+	})
+	return found, nil
+}
+
+// orfs finds the longest ORF ending at each stop codon of s read as a circle
+func orfs(s string, table codon.Interface, minCodons int, strand byte) []ORF {
+	n := len(s)
+	if n == 0 {
+		return nil
+	}
+	codonAt := func(i int) string {
+		return string([]byte{s[i], s[(i+1)%n], s[(i+2)%n]})
+	}
+	in := func(cdn string, cdns []string) bool {
+		for _, x := range cdns {
+			if x == cdn {
+				return true
+			}
+		}
+		return false
+	}
+	stops := make([]bool, n)
+	for i := range stops {
+		stops[i] = in(codonAt(i), table.StopCodons())
+	}
+
+	// dist is the length of the reading frame from each position through its next stop
+	// codon, or 0 if there is none within n letters. Positions 3 apart form cycles
+	// around the circle, each filled backwards from one of its stops.
+	dist := make([]int, n)
+	visited := make([]bool, n)
+	for i := 0; i < n; i++ {
+		if visited[i] {
+			continue
+		}
+		var cycle []int
+		for j := i; !visited[j]; j = (j + 3) % n {
+			visited[j] = true
+			cycle = append(cycle, j)
+		}
+		last := -1
+		for k, j := range cycle {
+			if stops[j] {
+				last = k
+			}
+		}
+		if last < 0 {
+			continue
+		}
+		for step := 0; step < len(cycle); step++ {
+			k := mod(last-step, len(cycle))
+			j := cycle[k]
+			switch next := dist[cycle[(k+1)%len(cycle)]]; {
+			case stops[j]:
+				dist[j] = 3
+			case next > 0 && next+3 <= n:
+				dist[j] = next + 3
+			}
+		}
+	}
+
+	longest := make(map[int]ORF)
+	for i := 0; i < n; i++ {
+		if dist[i] == 0 || dist[i]/3 < minCodons || !in(codonAt(i), table.StartCodons()) {
+			continue
+		}
+		end := (i + dist[i]) % n
+		if o, ok := longest[end]; !ok || dist[i] > o.End-o.Start {
+			longest[end] = ORF{i, i + dist[i], strand}
+		}
+	}
+	found := make([]ORF, 0, len(longest))
+	for _, o := range longest {
+		found = append(found, o)
+	}
+	return found
+}
+
+// mod is i modulo n, from 0 to n-1
+func mod(i, n int) int {
+	return ((i % n) + n) % n
+}
@@ -0,0 +1,94 @@
+package circular_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence/circular"
+	"github.com/sembio/go/bio/test"
+)
+
+// in is whether cdn is one of cdns
+func in(cdn string, cdns []string) bool {
+	for _, c := range cdns {
+		if c == cdn {
+			return true
+		}
+	}
+	return false
+}
+
+// naive finds ORFs on one strand of s by reading on from every start codon
+func naive(s string, table codon.Interface, minCodons int, strand byte) []circular.ORF {
+	n := len(s)
+	ss := s + s + s + s
+	longest := map[int]circular.ORF{}
+	for i := 0; i < n; i++ {
+		if !in(ss[i:i+3], table.StartCodons()) {
+			continue
+		}
+		for l := 3; l <= n; l += 3 {
+			if in(ss[i+l-3:i+l], table.StopCodons()) {
+				if o, ok := longest[(i+l)%n]; l/3 >= minCodons && (!ok || l > o.End-o.Start) {
+					longest[(i+l)%n] = circular.ORF{Start: i, End: i + l, Strand: strand}
+				}
+				break
+			}
+		}
+	}
+	var orfs []circular.ORF
+	for _, o := range longest {
+		if strand == '-' {
+			start := (n - o.End%n) % n
+			o = circular.ORF{Start: start, End: start + o.End - o.Start, Strand: '-'}
+		}
+		orfs = append(orfs, o)
+	}
+	return orfs
+}
+
+func TestORFs(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("ORFs finds the ORFs read on from every start codon",
+		prop.ForAll(
+			func(s string, minCodons int) bool {
+				table := codon.Standard{}
+				got, err := dna(s).ORFs(table, minCodons)
+				want := append(naive(s, table, minCodons, '+'), naive(test.RevComp(s), table, minCodons, '-')...)
+				sort.Slice(want, func(i, j int) bool {
+					if want[i].Start != want[j].Start {
+						return want[i].Start < want[j].Start
+					}
+					return want[i].Strand < want[j].Strand
+				})
+				if len(got) == 0 && len(want) == 0 {
+					return err == nil
+				}
+				return err == nil && reflect.DeepEqual(got, want)
+			},
+			gen.RegexMatch("[ACGT]{1,120}"),
+			gen.IntRange(1, 5),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func ExampleCircular_ORFs() {
+	// an ORF of 5 codons, ATG AAA CCC GGG TAA, across the origin
+	plasmid := dna("CCCGGGTAA" + "CCCCCCCCCC" + "ATGAAA")
+	orfs, _ := plasmid.ORFs(codon.Standard{}, 5)
+	for _, o := range orfs {
+		seq, _ := plasmid.Range(uint(o.Start), uint(o.End)%plasmid.Length())
+		fmt.Println(o.Start, o.End, string(o.Strand), seq)
+	}
+	// Output:
+	// 19 34 + ATGAAACCCGGGTAA
+}
@@ -0,0 +1,31 @@
+package circular
+
+import (
+	"github.com/sembio/go/bio/search/iupac"
+)
+
+// Wrap is the sequence read from its origin followed by its first extra letters, going
+// around as many times as needed, so that linear searches see matches of up to extra+1
+// letters across the origin. Matches starting at or after Length repeat earlier ones.
+func (c *Circular) Wrap(extra uint) string {
+	s := c.String()
+	out := make([]byte, 0, len(s)+int(extra))
+	out = append(out, s...)
+	for i := 0; len(s) > 0 && uint(i) < extra; i++ {
+		out = append(out, s[i%len(s)])
+	}
+	return string(out)
+}
+
+// Find finds every match of pattern on both strands, including matches across the
+// origin, which end past Length
+func (c *Circular) Find(p *iupac.Pattern) []iupac.Match {
+	n := int(c.Length())
+	var matches []iupac.Match
+	for _, m := range p.FindString(c.Wrap(uint(len(p.String()) - 1))) {
+		if m.Start < n {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
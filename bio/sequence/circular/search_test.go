@@ -0,0 +1,41 @@
+package circular_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sembio/go/bio/search/iupac"
+)
+
+func TestWrap(t *testing.T) {
+	for _, tc := range []struct {
+		seq   string
+		extra uint
+		want  string
+	}{
+		{"GATTACA", 0, "GATTACA"},
+		{"GATTACA", 3, "GATTACAGAT"},
+		{"GAT", 7, "GATGATGATG"},
+		{"", 3, ""},
+	} {
+		if got := dna(tc.seq).Wrap(tc.extra); got != tc.want {
+			t.Errorf("Want: %s, Got: %s", tc.want, got)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	ecoRI, _ := iupac.New("GAATTC")
+	c := dna("ATTCCCCCGA")
+	if got, want := c.Find(ecoRI), []iupac.Match{{Start: 8, End: 14, Strand: '+'}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	rotated, _ := c.Rotate(8)
+	if got, want := rotated.Find(ecoRI), []iupac.Match{{Start: 0, End: 6, Strand: '+'}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	gga, _ := iupac.New("GGAT")
+	if got, want := dna("CCAT").Find(gga), []iupac.Match{{Start: 2, End: 6, Strand: '-'}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
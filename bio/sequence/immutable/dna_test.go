@@ -321,6 +321,23 @@ func TestDnaMethodComplements(t *testing.T) {
 			gen.UIntRange(1, sequence.TestableLength),
 		),
 	)
+	properties.Property("RevComp() is Reverse().Complement()",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				orig, _ := immutable.NewDna(s)
+				rc, _ := orig.RevComp()
+				rev, _ := orig.Reverse()
+				comp, _ := rev.(*immutable.Dna).Complement()
+				return rc.(*immutable.Dna).String() == comp.(*immutable.Dna).String()
+			},
+			gen.UIntRange(1, sequence.TestableLength),
+		),
+	)
 	properties.TestingRun(t)
 }
 
@@ -483,6 +500,14 @@ func TestDnaParallelOperations(t *testing.T) {
 }
 
 // Building a new Dna from valid letters results in no error
+func TestDnaRevCompOddLength(t *testing.T) {
+	s, _ := immutable.NewDna("ACGTA")
+	rc, err := s.RevComp()
+	if got := rc.(*immutable.Dna).String(); err != nil || got != "TACGT" {
+		t.Errorf("Want: %q, Got: %q %v", "TACGT", got, err)
+	}
+}
+
 func ExampleNewDna_errorless() {
 	s, err := immutable.NewDna("ATGC")
 
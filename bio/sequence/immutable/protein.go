@@ -3,17 +3,21 @@ package immutable
 import (
 	"github.com/sembio/go/bio/alphabet"
 	"github.com/sembio/go/bio/alphabet/hashmap"
+	gencode "github.com/sembio/go/bio/data/codon"
 	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/internal/backtranslate"
+	"github.com/sembio/go/bio/stats/codon"
 )
 
 var _ sequence.Reverser = new(Protein)
+var _ sequence.BackTranslater = new(Protein)
 var _ sequence.Alphabeter = new(Protein)
 var _ sequence.LetterCounter = new(Protein)
 var _ sequence.Validator = new(Protein)
 var _ Wither = new(Protein)
 
 // Protein is a sequence which validates against the Protein alphabet
-// and knows how to reverse itself
+// and knows how to reverse and back-translate itself
 type Protein struct {
 	*Struct
 }
@@ -37,6 +41,29 @@ func (x *Protein) Reverse() (sequence.Interface, error) {
 	return NewProtein(string(t))
 }
 
+// BackTranslate returns the IUPAC DnaIupac covering every genetic product
+// that could have made the Protein when using a codon table.
+// See codon.Ambiguous for how each amino acid is covered.
+func (x *Protein) BackTranslate(table gencode.Interface) (sequence.Interface, error) {
+	t, err := backtranslate.Ambiguous(x.String(), table)
+	if err != nil {
+		return nil, err
+	}
+	return NewDnaIupac(t)
+}
+
+// BackTranslateMostFrequent returns a concrete Dna that translates to the Protein
+// when using a codon table, choosing for each amino acid its most used synonymous
+// codon in usage. Ties, including codons missing from usage, go to the first codon in
+// sorted order.
+func (x *Protein) BackTranslateMostFrequent(table gencode.Interface, usage *codon.Usage) (sequence.Interface, error) {
+	t, err := backtranslate.MostFrequent(x.String(), table, usage)
+	if err != nil {
+		return nil, err
+	}
+	return NewDna(t)
+}
+
 // Alphabet reveals the underlying alphabet in use
 func (x *Protein) Alphabet() alphabet.Interface {
 	return hashmap.NewProtein()
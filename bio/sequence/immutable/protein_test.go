@@ -7,8 +7,10 @@ import (
 
 	"github.com/sembio/go/bio/alphabet"
 	"github.com/sembio/go/bio/alphabet/hashmap"
+	gencode "github.com/sembio/go/bio/data/codon"
 	"github.com/sembio/go/bio/sequence"
 	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/codon"
 	"github.com/sembio/go/bio/test"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -301,6 +303,50 @@ func TestProteinParallelOperations(t *testing.T) {
 	properties.TestingRun(t)
 }
 
+func TestProteinBackTranslation(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Protein is 1/3x length as back-translation",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewProtein().String()),
+				)
+				seq, _ := immutable.NewProtein(s)
+				back, err := seq.BackTranslate(gencode.Standard{})
+				return err == nil && back.Length() == seq.Length()*3
+			},
+			gen.UIntRange(1, sequence.TestableLength),
+		),
+	)
+	properties.Property("BackTranslateMostFrequent().Translate() is original",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewProtein().String()),
+				)
+				seq, _ := immutable.NewProtein(s)
+				usage := codon.NewUsage()
+				known, _ := immutable.NewDna("CTGCTGTTAAGCAGC")
+				usage.Add(known, 1)
+				back, err := seq.BackTranslateMostFrequent(gencode.Standard{}, usage)
+				if err != nil {
+					return false
+				}
+				got, err := back.(*immutable.Dna).Translate(gencode.Standard{}, '*')
+				return err == nil && got.(*immutable.Protein).String() == s
+			},
+			gen.UIntRange(1, sequence.TestableLength),
+		),
+	)
+	properties.TestingRun(t)
+}
+
 // Building a new Protein from valid letters results in no error
 func ExampleNewProtein_errorless() {
 	s, err := immutable.NewProtein("ACDEFGHIKLMNPQRSTVWY")
@@ -343,6 +389,29 @@ func ExampleProtein_Reverse_errored() {
 	// &YWVTSRQPNMLKIHGFEDCA%, "&" not in alphabet
 }
 
+// Back-translating a Protein covers all synonymous codons with IUPAC letters
+func ExampleProtein_BackTranslate() {
+	s, _ := immutable.NewProtein("MLW")
+	back, err := s.BackTranslate(gencode.Standard{})
+
+	fmt.Printf("%s, %v", back, err)
+	// Output:
+	// ATGYTNTGG, <nil>
+}
+
+// Back-translating a Protein by usage chooses the most frequent synonymous codon
+func ExampleProtein_BackTranslateMostFrequent() {
+	s, _ := immutable.NewProtein("MLW")
+	known, _ := immutable.NewDna("CTGCTGTTA")
+	usage := codon.NewUsage()
+	usage.Add(known, 1)
+	back, err := s.BackTranslateMostFrequent(gencode.Standard{}, usage)
+
+	fmt.Printf("%s, %v", back, err)
+	// Output:
+	// ATGCTGTGG, <nil>
+}
+
 // Note that the alphabet gets sorted and would be
 // unaffected by an invalid input to immutable.NewProtein()
 func ExampleProtein_Alphabet() {
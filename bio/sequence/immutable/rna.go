@@ -47,7 +47,7 @@ func (x *Rna) RevComp() (sequence.Interface, error) {
 	c := x.Alphabet().(alphabet.Complementer)
 	l := x.Length()
 	t := []byte(x.seq)
-	for i := uint(0); i < l/2; i++ {
+	for i := uint(0); i < (l+1)/2; i++ {
 		t[i], t[l-1-i] = byte(c.Complement(string(t[l-1-i]))[0]), byte(c.Complement(string(t[i]))[0])
 	}
 	return NewRna(string(t))
@@ -428,6 +428,14 @@ func TestRnaIupacParallelOperations(t *testing.T) {
 }
 
 // Building a new RnaIupac from valid letters results in no error
+func TestRnaIupacRevCompOddLength(t *testing.T) {
+	s, _ := immutable.NewRnaIupac("ACRUN")
+	rc, err := s.RevComp()
+	if got := rc.(*immutable.RnaIupac).String(); err != nil || got != "NAYGU" {
+		t.Errorf("Want: %q, Got: %q %v", "NAYGU", got, err)
+	}
+}
+
 func ExampleNewRnaIupac_errorless() {
 	s, err := immutable.NewRnaIupac("RYSWKM" + "BDHV" + "N" + "AUGC" + "-")
 
@@ -430,6 +430,14 @@ func TestRnaParallelOperations(t *testing.T) {
 }
 
 // Building a new Rna from valid letters results in no error
+func TestRnaRevCompOddLength(t *testing.T) {
+	s, _ := immutable.NewRna("ACGUA")
+	rc, err := s.RevComp()
+	if got := rc.(*immutable.Rna).String(); err != nil || got != "UACGU" {
+		t.Errorf("Want: %q, Got: %q %v", "UACGU", got, err)
+	}
+}
+
 func ExampleNewRna_errorless() {
 	s, err := immutable.NewRna("AUGC")
 
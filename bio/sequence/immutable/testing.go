@@ -0,0 +1,19 @@
+package immutable
+
+// TestDna is s as Dna, panicking if s is not DNA
+func TestDna(s string) *Dna {
+	d, err := NewDna(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// TestDnaIupac is s as DnaIupac, panicking if s is not IUPAC DNA
+func TestDnaIupac(s string) *DnaIupac {
+	d, err := NewDnaIupac(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -52,3 +52,8 @@ type Alphabeter interface {
 type LetterCounter interface {
 	LetterCount() map[string]uint
 }
+
+// BackTranslater can back-translate the sequence
+type BackTranslater interface {
+	BackTranslate(codon.Interface) (Interface, error)
+}
@@ -0,0 +1,45 @@
+package backtranslate
+
+import (
+	"fmt"
+
+	gencode "github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/stats/codon"
+)
+
+// Ambiguous is IUPAC DNA covering every DNA that translates to protein seq when using
+// a codon table, with each amino acid covered as by codon.Ambiguous.
+func Ambiguous(seq string, table gencode.Interface) (string, error) {
+	t := make([]byte, 0, len(seq)*3)
+	amb := make(map[byte]string)
+	for i := range seq {
+		if _, ok := amb[seq[i]]; !ok {
+			cdn, ok := gencode.Ambiguous(table, seq[i])
+			if !ok {
+				return "", fmt.Errorf("failed to back-translate amino acid: %q when using %s", seq[i], table)
+			}
+			amb[seq[i]] = cdn
+		}
+		t = append(t, amb[seq[i]]...)
+	}
+	return string(t), nil
+}
+
+// MostFrequent is DNA that translates to protein seq when using a codon table,
+// choosing for each amino acid its most used synonymous codon in usage. Ties,
+// including codons missing from usage, go to the first codon in sorted order.
+func MostFrequent(seq string, table gencode.Interface, usage *codon.Usage) (string, error) {
+	t := make([]byte, 0, len(seq)*3)
+	best := make(map[byte]string)
+	for i := range seq {
+		if _, ok := best[seq[i]]; !ok {
+			cdns := gencode.Codons(table, seq[i])
+			if len(cdns) == 0 {
+				return "", fmt.Errorf("failed to back-translate amino acid: %q when using %s", seq[i], table)
+			}
+			best[seq[i]] = usage.MostUsed(cdns)
+		}
+		t = append(t, best[seq[i]]...)
+	}
+	return string(t), nil
+}
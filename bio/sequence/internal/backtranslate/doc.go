@@ -0,0 +1,5 @@
+/*
+Package backtranslate holds the back-translation shared by the immutable and mutable
+sequence packages.
+*/
+package backtranslate
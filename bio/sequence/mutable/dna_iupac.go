@@ -47,7 +47,7 @@ func (x *DnaIupac) RevComp() (sequence.Interface, error) {
 	l := x.Length()
 	t := make([]string, l)
 	var pos1, pos2 string
-	for i := uint(0); i < l/2; i++ {
+	for i := uint(0); i < (l+1)/2; i++ {
 		pos1, _ = x.Position(i)
 		pos2, _ = x.Position(l - 1 - i)
 		t[i], t[l-1-i] = c.Complement(pos2), c.Complement(pos1)
@@ -469,6 +469,14 @@ func TestDnaIupacParallelOperations(t *testing.T) {
 }
 
 // Building a new DnaIupac from valid letters results in no error
+func TestDnaIupacRevCompOddLength(t *testing.T) {
+	s, _ := mutable.NewDnaIupac("ACRTN")
+	rc, err := s.RevComp()
+	if got := rc.(*mutable.DnaIupac).String(); err != nil || got != "NAYGT" {
+		t.Errorf("Want: %q, Got: %q %v", "NAYGT", got, err)
+	}
+}
+
 func ExampleNewDnaIupac_errorless() {
 	s, err := mutable.NewDnaIupac("RYSWKM" + "BDHV" + "N" + "ATGC" + "-")
 
@@ -323,6 +323,24 @@ func TestDnaMethodComplements(t *testing.T) {
 			gen.UIntRange(1, sequence.TestableLength),
 		),
 	)
+	properties.Property("RevComp() is Reverse().Complement()",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				a, _ := mutable.NewDna(s)
+				b, _ := mutable.NewDna(s)
+				rc, _ := a.RevComp()
+				rev, _ := b.Reverse()
+				comp, _ := rev.(*mutable.Dna).Complement()
+				return rc.(*mutable.Dna).String() == comp.(*mutable.Dna).String()
+			},
+			gen.UIntRange(1, sequence.TestableLength),
+		),
+	)
 	properties.TestingRun(t)
 }
 
@@ -485,6 +503,22 @@ func TestDnaParallelOperations(t *testing.T) {
 }
 
 // Building a new Dna from valid letters results in no error
+func TestDnaRevCompOddLength(t *testing.T) {
+	s, _ := mutable.NewDna("ACGTA")
+	rc, err := s.RevComp()
+	if got := rc.(*mutable.Dna).String(); err != nil || got != "TACGT" {
+		t.Errorf("Want: %q, Got: %q %v", "TACGT", got, err)
+	}
+}
+
+func TestDnaReverseOddLength(t *testing.T) {
+	s, _ := mutable.NewDna("ACGTA")
+	r, err := s.Reverse()
+	if got := r.(*mutable.Dna).String(); err != nil || got != "ATGCA" {
+		t.Errorf("Want: %q, Got: %q %v", "ATGCA", got, err)
+	}
+}
+
 func ExampleNewDna_errorless() {
 	s, err := mutable.NewDna("ATGC")
 
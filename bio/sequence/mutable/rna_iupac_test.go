@@ -430,6 +430,14 @@ func TestRnaIupacParallelOperations(t *testing.T) {
 }
 
 // Building a new RnaIupac from valid letters results in no error
+func TestRnaIupacRevCompOddLength(t *testing.T) {
+	s, _ := mutable.NewRnaIupac("ACRUN")
+	rc, err := s.RevComp()
+	if got := rc.(*mutable.RnaIupac).String(); err != nil || got != "NAYGU" {
+		t.Errorf("Want: %q, Got: %q %v", "NAYGU", got, err)
+	}
+}
+
 func ExampleNewRnaIupac_errorless() {
 	s, err := mutable.NewRnaIupac("RYSWKM" + "BDHV" + "N" + "AUGC" + "-")
 
@@ -0,0 +1,8 @@
+/*
+Package simulate samples sequencing reads from a reference sequence for benchmarking.
+
+Reads follow an ErrorModel of substitutions and indels that can change along the read,
+their qualities are the error probabilities used to make them, and the header of each
+read records where it truly came from (see Origin). Reads are reproducible from their seed.
+*/
+package simulate
@@ -0,0 +1,44 @@
+package simulate
+
+// ErrorModel gives the chance of each kind of sequencing error at a position of a read
+type ErrorModel interface {
+	// Rates are the substitution, insertion, and deletion probabilities at position i
+	// (from 0) of a read of length n
+	Rates(i, n int) (sub, ins, del float64)
+}
+
+var _ ErrorModel = Profile{}
+
+// Profile is an ErrorModel whose substitution rate changes linearly from the
+// first to the last base of a read and whose indel rates are constant
+type Profile struct {
+	SubFirst float64
+	SubLast  float64
+	Ins      float64
+	Del      float64
+}
+
+// Illumina is a Profile like that of short Illumina reads, with substitutions
+// rising towards the 3' end and indels rare
+func Illumina() Profile {
+	return Profile{
+		SubFirst: 0.001,
+		SubLast:  0.01,
+		Ins:      0.00005,
+		Del:      0.0001,
+	}
+}
+
+// Perfect is a Profile with no errors
+func Perfect() Profile {
+	return Profile{}
+}
+
+// Rates are the substitution, insertion, and deletion probabilities at position i of a read of length n
+func (p Profile) Rates(i, n int) (float64, float64, float64) {
+	sub := p.SubFirst
+	if n > 1 {
+		sub += (p.SubLast - p.SubFirst) * float64(i) / float64(n-1)
+	}
+	return sub, p.Ins, p.Del
+}
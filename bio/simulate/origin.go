@@ -0,0 +1,54 @@
+package simulate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Origin is where in the reference a read truly came from,
+// as the half-open range [Start, End) of the forward strand
+type Origin struct {
+	Ref    string
+	Start  int
+	End    int
+	Strand byte
+}
+
+// String writes the Origin as it appears in read headers, for example chr1:100-250:+
+func (o Origin) String() string {
+	return fmt.Sprintf("%s:%d-%d:%c", o.Ref, o.Start, o.End, o.Strand)
+}
+
+// ParseOrigin reads the Origin from the header of a simulated read
+func ParseOrigin(header string) (Origin, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 2 {
+		return Origin{}, fmt.Errorf("header %q has no origin", header)
+	}
+	s := fields[1]
+	o := Origin{}
+	colon := strings.LastIndexByte(s, ':')
+	if colon < 0 || colon != len(s)-2 || (s[colon+1] != '+' && s[colon+1] != '-') {
+		return o, fmt.Errorf("origin %q has no strand", s)
+	}
+	o.Strand = s[colon+1]
+	s = s[:colon]
+	colon = strings.LastIndexByte(s, ':')
+	if colon < 0 {
+		return o, fmt.Errorf("origin %q has no range", fields[1])
+	}
+	o.Ref = s[:colon]
+	bounds := strings.Split(s[colon+1:], "-")
+	if len(bounds) != 2 {
+		return o, fmt.Errorf("origin %q has no range", fields[1])
+	}
+	var err error
+	if o.Start, err = strconv.Atoi(bounds[0]); err != nil {
+		return o, err
+	}
+	if o.End, err = strconv.Atoi(bounds[1]); err != nil {
+		return o, err
+	}
+	return o, nil
+}
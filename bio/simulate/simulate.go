@@ -0,0 +1,232 @@
+package simulate
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Simulator samples reads from a reference sequence
+type Simulator struct {
+	fwd, rev   string
+	name       string
+	length     int
+	model      ErrorModel
+	insertMean float64
+	insertSD   float64
+	enc        quality.Encoding
+	seed       int64
+	rng        *rand.Rand
+	count      int
+}
+
+// Option is a setting of a Simulator
+type Option func(*Simulator)
+
+// ReadLengthIs sets the length of the reads (100 by default)
+func ReadLengthIs(n uint) Option {
+	return func(s *Simulator) {
+		s.length = int(n)
+	}
+}
+
+// ErrorModelIs sets the ErrorModel of the reads (Illumina by default)
+func ErrorModelIs(m ErrorModel) Option {
+	return func(s *Simulator) {
+		s.model = m
+	}
+}
+
+// InsertSizeIs sets the mean and standard deviation of the normally distributed
+// length of the fragments read pairs are read from (300 and 30 by default)
+func InsertSizeIs(mean, sd float64) Option {
+	return func(s *Simulator) {
+		s.insertMean = mean
+		s.insertSD = sd
+	}
+}
+
+// EncodingIs sets the Encoding of the qualities (quality.Illumina18 by default)
+func EncodingIs(e quality.Encoding) Option {
+	return func(s *Simulator) {
+		s.enc = e
+	}
+}
+
+// NameIs sets the name of the reference in the Origin of each read ("ref" by default)
+func NameIs(name string) Option {
+	return func(s *Simulator) {
+		s.name = name
+	}
+}
+
+// SeedIs sets the seed of the Simulator (1 by default)
+// so the same reference, settings, and seed always produce the same reads
+func SeedIs(seed int64) Option {
+	return func(s *Simulator) {
+		s.seed = seed
+	}
+}
+
+// New generates a Simulator for a reference that can be reverse complemented
+func New(ref sequence.Interface, opts ...Option) (*Simulator, error) {
+	rc, ok := ref.(sequence.RevComper)
+	if !ok {
+		return nil, fmt.Errorf("reference cannot be reverse complemented")
+	}
+	s := &Simulator{
+		name:       "ref",
+		length:     100,
+		model:      Illumina(),
+		insertMean: 300,
+		insertSD:   30,
+		enc:        quality.Illumina18,
+		seed:       1,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	var err error
+	if s.fwd, err = ref.Range(0, ref.Length()); err != nil {
+		return nil, err
+	}
+	rev, err := rc.RevComp()
+	if err != nil {
+		return nil, err
+	}
+	if s.rev, err = rev.Range(0, rev.Length()); err != nil {
+		return nil, err
+	}
+	s.fwd, s.rev = strings.ToUpper(s.fwd), strings.ToUpper(s.rev)
+	if s.length <= 0 || len(s.fwd) < s.length {
+		return nil, fmt.Errorf("reference length [%d] is shorter than the read length [%d]", len(s.fwd), s.length)
+	}
+	s.rng = rand.New(rand.NewSource(s.seed))
+	return s, nil
+}
+
+// Read samples a single-end read whose header is its number and Origin
+func (s *Simulator) Read() fastq.Interface {
+	s.count++
+	// extra template beyond the read length allows for deletions
+	n := s.length + s.length/10 + 10
+	if n > len(s.fwd) {
+		n = len(s.fwd)
+	}
+	start := s.rng.Intn(len(s.fwd) - n + 1)
+	strand := s.strand()
+	seq, qual, used := s.sequence(s.template(start, start+n, strand))
+	o := s.origin(start, start+n, strand, used)
+	return fastq.New(fmt.Sprintf("sim%d %s", s.count, o), qual, immutable.New(seq))
+}
+
+// Pair samples a read pair from either end of a fragment, whose headers are their
+// shared number with "/1" or "/2" and their Origin
+func (s *Simulator) Pair() (fastq.Interface, fastq.Interface) {
+	s.count++
+	n := int(math.Round(s.rng.NormFloat64()*s.insertSD + s.insertMean))
+	switch {
+	case n < 1:
+		n = 1
+	case n > len(s.fwd):
+		n = len(s.fwd)
+	}
+	start := s.rng.Intn(len(s.fwd) - n + 1)
+	strand := s.strand()
+	other := byte('-')
+	if strand == '-' {
+		other = '+'
+	}
+
+	seq1, qual1, used1 := s.sequence(s.template(start, start+n, strand))
+	seq2, qual2, used2 := s.sequence(s.template(start, start+n, other))
+	o1 := s.origin(start, start+n, strand, used1)
+	o2 := s.origin(start, start+n, other, used2)
+	return fastq.New(fmt.Sprintf("sim%d/1 %s", s.count, o1), qual1, immutable.New(seq1)),
+		fastq.New(fmt.Sprintf("sim%d/2 %s", s.count, o2), qual2, immutable.New(seq2))
+}
+
+// strand chooses a strand at random
+func (s *Simulator) strand() byte {
+	if s.rng.Intn(2) == 0 {
+		return '+'
+	}
+	return '-'
+}
+
+// template is the fragment [start, end) of the forward strand as read from strand
+func (s *Simulator) template(start, end int, strand byte) string {
+	if strand == '-' {
+		return s.rev[len(s.fwd)-end : len(s.fwd)-start]
+	}
+	return s.fwd[start:end]
+}
+
+// origin is where a read that used the first used bases of the template
+// of fragment [start, end) on strand came from
+func (s *Simulator) origin(start, end int, strand byte, used int) Origin {
+	if strand == '-' {
+		return Origin{Ref: s.name, Start: end - used, End: end, Strand: strand}
+	}
+	return Origin{Ref: s.name, Start: start, End: start + used, Strand: strand}
+}
+
+// sequence reads a template with errors, returning the read, its qualities,
+// and how many bases of the template were used.
+// Reads are shorter than the read length only where the template runs out.
+func (s *Simulator) sequence(template string) (string, string, int) {
+	seq := make([]byte, 0, s.length)
+	qual := make([]byte, 0, s.length)
+	t := 0
+	for len(seq) < s.length && t < len(template) {
+		i := len(seq)
+		sub, ins, del := s.model.Rates(i, s.length)
+		switch r := s.rng.Float64(); {
+		case r < del:
+			t++
+			continue
+		case r < del+ins:
+			seq = append(seq, "ACGT"[s.rng.Intn(4)])
+		default:
+			// qualities vary around the substitution rate, and errors follow them
+			p := math.Min(sub*math.Exp(s.rng.NormFloat64()*0.5), 0.75)
+			base := template[t]
+			if s.rng.Float64() < p {
+				base = substitute(base, s.rng)
+			}
+			seq = append(seq, base)
+			sub = p
+			t++
+		}
+		if s.enc == quality.Solexa {
+			qual = append(qual, s.enc.Encode(score(quality.PhredToSolexa(-10*math.Log10(sub)))))
+		} else {
+			qual = append(qual, s.enc.Encode(score(-10*math.Log10(sub))))
+		}
+	}
+	return string(seq), string(qual), t
+}
+
+// substitute chooses a different base than b
+func substitute(b byte, rng *rand.Rand) byte {
+	for {
+		if c := "ACGT"[rng.Intn(4)]; c != b {
+			return c
+		}
+	}
+}
+
+// score rounds a quality score, which is infinite for error-free bases
+func score(q float64) int8 {
+	if q = math.Round(q); q > math.MaxInt8 {
+		return math.MaxInt8
+	}
+	return int8(q)
+}
@@ -0,0 +1,204 @@
+package simulate_test
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/simulate"
+	"github.com/sembio/go/bio/test"
+)
+
+func reference(t *testing.T, n uint) *immutable.Dna {
+	ref, err := immutable.NewDna(test.RandomStringFromRunes(test.Seed, n, []rune(hashmap.NewDna().String())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ref
+}
+
+// truth is the reference sequence a read came from, on its strand
+func truth(t *testing.T, ref *immutable.Dna, header string) string {
+	o, err := simulate.ParseOrigin(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part, _ := immutable.NewDna(ref.String()[o.Start:o.End])
+	if o.Strand == '-' {
+		rc, _ := part.RevComp()
+		s, _ := rc.Range(0, rc.Length())
+		return s
+	}
+	return part.String()
+}
+
+func TestNew(t *testing.T) {
+	if _, err := simulate.New(reference(t, 50)); err == nil {
+		t.Error("references shorter than reads should error")
+	}
+	if _, err := simulate.New(immutable.New("ACGT"), simulate.ReadLengthIs(2)); err == nil {
+		t.Error("references that cannot be reverse complemented should error")
+	}
+}
+
+func TestPerfectReads(t *testing.T) {
+	ref := reference(t, 2000)
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Error-free reads match their origin",
+		prop.ForAll(
+			func(seed int64, length uint) bool {
+				s, err := simulate.New(ref,
+					simulate.SeedIs(seed),
+					simulate.ReadLengthIs(length),
+					simulate.ErrorModelIs(simulate.Perfect()),
+				)
+				if err != nil {
+					return false
+				}
+				r := s.Read()
+				r1, r2 := s.Pair()
+				for _, rec := range []fastq.Interface{r, r1, r2} {
+					if rec.Sequence() != truth(t, ref, rec.Header()) ||
+						len(rec.Quality()) != len(rec.Sequence()) {
+						return false
+					}
+				}
+				return len(r.Sequence()) == int(length) && fastq.CheckMates(r1, r2) == nil
+			},
+			gen.Int64(),
+			gen.UIntRange(1, 250),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestReproducible(t *testing.T) {
+	ref := reference(t, 1000)
+	sample := func(seed int64) []string {
+		s, err := simulate.New(ref, simulate.SeedIs(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make([]string, 0)
+		for i := 0; i < 10; i++ {
+			r := s.Read()
+			r1, r2 := s.Pair()
+			out = append(out, r.Header(), r.Sequence(), r.Quality(), r1.Sequence(), r2.Quality())
+		}
+		return out
+	}
+	if !reflect.DeepEqual(sample(7), sample(7)) {
+		t.Error("the same seed should simulate the same reads")
+	}
+	if reflect.DeepEqual(sample(7), sample(8)) {
+		t.Error("different seeds should simulate different reads")
+	}
+}
+
+func TestPairs(t *testing.T) {
+	ref := reference(t, 5000)
+	s, err := simulate.New(ref, simulate.InsertSizeIs(400, 20), simulate.ErrorModelIs(simulate.Perfect()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, n := 0.0, 500
+	for i := 0; i < n; i++ {
+		r1, r2 := s.Pair()
+		o1, _ := simulate.ParseOrigin(r1.Header())
+		o2, _ := simulate.ParseOrigin(r2.Header())
+		if o1.Strand == o2.Strand {
+			t.Fatalf("mates should be on opposite strands: %s %s", o1, o2)
+		}
+		start, end := o1.Start, o2.End
+		if o1.Strand == '-' {
+			start, end = o2.Start, o1.End
+		}
+		sum += float64(end - start)
+	}
+	if mean := sum / float64(n); math.Abs(mean-400) > 5 {
+		t.Errorf("Want: mean insert near 400, Got: %f", mean)
+	}
+}
+
+func TestErrorModel(t *testing.T) {
+	ref := reference(t, 5000)
+	s, err := simulate.New(ref, simulate.ErrorModelIs(simulate.Profile{SubFirst: 0.01, SubLast: 0.05}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, last, expected, n := 0, 0, 0.0, 2000
+	for i := 0; i < n; i++ {
+		r := s.Read()
+		want := truth(t, ref, r.Header())
+		got := r.Sequence()
+		if got[0] != want[0] {
+			first++
+		}
+		if got[len(got)-1] != want[len(want)-1] {
+			last++
+		}
+		scores, err := quality.Decode(r.Quality(), quality.Illumina18)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected += scores.ExpectedErrors()
+	}
+	if first >= last {
+		t.Errorf("errors should rise along the read, Got: %d first and %d last", first, last)
+	}
+	if perBase := expected / float64(n*100); perBase < 0.025 || perBase > 0.04 {
+		t.Errorf("Want: qualities near the error rate, Got: %f expected errors per base", perBase)
+	}
+}
+
+func TestParseOrigin(t *testing.T) {
+	tt := []struct {
+		header string
+		want   simulate.Origin
+		fails  bool
+	}{
+		{"sim1 chr1:100-250:+", simulate.Origin{Ref: "chr1", Start: 100, End: 250, Strand: '+'}, false},
+		{"sim2/2 chr1:v2:0-50:-", simulate.Origin{Ref: "chr1:v2", Start: 0, End: 50, Strand: '-'}, false},
+		{"sim3", simulate.Origin{}, true},
+		{"sim4 chr1:100-250", simulate.Origin{}, true},
+		{"sim5 chr1:100:+", simulate.Origin{}, true},
+		{"sim6 chr1:a-250:+", simulate.Origin{}, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.header, func(t *testing.T) {
+			got, err := simulate.ParseOrigin(tc.header)
+			if (err != nil) != tc.fails {
+				t.Fatalf("Want error: %v, Got: %v", tc.fails, err)
+			}
+			if !tc.fails && got != tc.want {
+				t.Errorf("Want: %v, Got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func ExampleSimulator_Pair() {
+	ref, _ := immutable.NewDna("ACGTTGCAAGGCTTACCGATAGCTAGGCTTAACGGTACCATGA")
+	s, _ := simulate.New(ref,
+		simulate.NameIs("chr1"),
+		simulate.ReadLengthIs(8),
+		simulate.InsertSizeIs(20, 0),
+		simulate.ErrorModelIs(simulate.Perfect()),
+	)
+	r1, r2 := s.Pair()
+	o1, _ := simulate.ParseOrigin(r1.Header())
+	o2, _ := simulate.ParseOrigin(r2.Header())
+
+	fmt.Println(o2.End-o1.Start == 20 || o1.End-o2.Start == 20, len(r1.Sequence()), len(r2.Sequence()))
+	// Output: true 8 8
+}
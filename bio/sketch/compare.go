@@ -0,0 +1,78 @@
+package sketch
+
+import (
+	"math"
+	"sort"
+)
+
+// Jaccard estimates the Jaccard similarity of the k-mers of two sketched collections:
+// the k-mers they share over all of their k-mers. Bottom-k sketches are compared over
+// the lowest hashes of their union, as Mash does.
+func Jaccard(a, b *Sketch) (float64, error) {
+	if err := a.compatible(b); err != nil {
+		return 0, err
+	}
+	union := make([]uint64, 0, len(a.hashes)+len(b.hashes))
+	for h := range a.hashes {
+		union = append(union, h)
+	}
+	for h := range b.hashes {
+		if !a.hashes[h] {
+			union = append(union, h)
+		}
+	}
+	if a.kind == BottomK {
+		sort.Slice(union, func(i, j int) bool {
+			return union[i] < union[j]
+		})
+		if uint64(len(union)) > a.param {
+			union = union[:a.param]
+		}
+	}
+	if len(union) == 0 {
+		return 0, nil
+	}
+	shared := 0
+	for _, h := range union {
+		if a.hashes[h] && b.hashes[h] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(union)), nil
+}
+
+// Distance is the Mash distance between two sketched collections, which estimates
+// the rate of mutations per base separating them from their Jaccard similarity
+func Distance(a, b *Sketch) (float64, error) {
+	j, err := Jaccard(a, b)
+	if err != nil || j == 0 {
+		return 1, err
+	}
+	return -math.Log(2*j/(1+j)) / float64(a.k), nil
+}
+
+// Containment estimates the fraction of the k-mers of a found in b. Bottom-k sketches
+// are compared over the hashes of a no higher than the highest hash of b when b is full.
+func Containment(a, b *Sketch) (float64, error) {
+	if err := a.compatible(b); err != nil {
+		return 0, err
+	}
+	max := uint64(math.MaxUint64)
+	if b.kind == BottomK && uint64(len(b.bottom)) == b.param {
+		max = b.bottom[0]
+	}
+	total, shared := 0, 0
+	for h := range a.hashes {
+		if h > max {
+			continue
+		}
+		total++
+		if b.hashes[h] {
+			shared++
+		}
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(shared) / float64(total), nil
+}
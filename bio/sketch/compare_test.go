@@ -0,0 +1,136 @@
+package sketch_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/sketch"
+	"github.com/sembio/go/bio/test"
+)
+
+// jaccard is the exact Jaccard similarity of the canonical k-mers of two sequences
+func jaccard(a, b string, k uint) float64 {
+	ca, _ := kmer.NewCounter(k, true)
+	cb, _ := kmer.NewCounter(k, true)
+	ca.Add(immutable.New(a))
+	cb.Add(immutable.New(b))
+	shared := 0
+	for _, x := range ca.Kmers() {
+		if cb.Count(x) != 0 {
+			shared++
+		}
+	}
+	return float64(shared) / float64(int(ca.Distinct())+int(cb.Distinct())-shared)
+}
+
+func TestJaccard(t *testing.T) {
+	shared := random(test.Seed, 20000)
+	a := shared + random(test.Seed+1, 10000)
+	b := shared + random(test.Seed+2, 10000)
+	want := jaccard(a, b, 21)
+	for _, tc := range []struct {
+		name string
+		new  func() (*sketch.Sketch, error)
+	}{
+		{"BottomK", func() (*sketch.Sketch, error) { return sketch.NewBottomK(21, 2000) }},
+		{"Frac", func() (*sketch.Sketch, error) { return sketch.NewFrac(21, 20) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa, _ := tc.new()
+			sb, _ := tc.new()
+			sketchOf(t, sa, a)
+			sketchOf(t, sb, b)
+			got, err := sketch.Jaccard(sa, sb)
+			if err != nil || math.Abs(got-want) > 0.05 {
+				t.Errorf("Want: about %f, Got: %f %v", want, got, err)
+			}
+			if self, _ := sketch.Jaccard(sa, sa); self != 1 {
+				t.Errorf("Want: 1 against itself, Got: %f", self)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	a := random(test.Seed, 50000)
+	for _, rate := range []float64{0.001, 0.01, 0.05} {
+		t.Run(fmt.Sprint(rate), func(t *testing.T) {
+			sa, _ := sketch.NewBottomK(21, 5000)
+			sb, _ := sketch.NewBottomK(21, 5000)
+			sketchOf(t, sa, a)
+			sketchOf(t, sb, test.RevComp(mutate(test.Seed, a, rate)))
+			got, err := sketch.Distance(sa, sb)
+			if err != nil || math.Abs(got-rate) > rate/3+0.001 {
+				t.Errorf("Want: about %f, Got: %f %v", rate, got, err)
+			}
+		})
+	}
+	sa, _ := sketch.NewBottomK(21, 100)
+	sb, _ := sketch.NewBottomK(21, 100)
+	sketchOf(t, sa, random(test.Seed, 1000))
+	sketchOf(t, sb, random(test.Seed+1, 1000))
+	if d, err := sketch.Distance(sa, sb); d != 1 || err != nil {
+		t.Errorf("Want: 1 for unrelated sequences, Got: %f %v", d, err)
+	}
+}
+
+func TestContainment(t *testing.T) {
+	part := random(test.Seed, 20000)
+	whole := part + random(test.Seed+1, 20000)
+	for _, tc := range []struct {
+		name string
+		new  func() (*sketch.Sketch, error)
+	}{
+		{"BottomK", func() (*sketch.Sketch, error) { return sketch.NewBottomK(21, 2000) }},
+		{"Frac", func() (*sketch.Sketch, error) { return sketch.NewFrac(21, 10) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sp, _ := tc.new()
+			sw, _ := tc.new()
+			sketchOf(t, sp, part)
+			sketchOf(t, sw, whole)
+			if c, err := sketch.Containment(sp, sw); err != nil || c < 0.99 {
+				t.Errorf("Want: part contained in whole, Got: %f %v", c, err)
+			}
+			if c, err := sketch.Containment(sw, sp); err != nil || math.Abs(c-0.5) > 0.07 {
+				t.Errorf("Want: about half of whole in part, Got: %f %v", c, err)
+			}
+		})
+	}
+}
+
+func TestCompareErrors(t *testing.T) {
+	a, _ := sketch.NewBottomK(21, 100)
+	for _, b := range []func() (*sketch.Sketch, error){
+		func() (*sketch.Sketch, error) { return sketch.NewBottomK(19, 100) },
+		func() (*sketch.Sketch, error) { return sketch.NewBottomK(21, 200) },
+		func() (*sketch.Sketch, error) { return sketch.NewFrac(21, 100) },
+		func() (*sketch.Sketch, error) { return sketch.NewBottomK(21, 100, sketch.SeedIs(1)) },
+	} {
+		other, _ := b()
+		if _, err := sketch.Jaccard(a, other); err == nil {
+			t.Error("Want: error comparing incompatible sketches")
+		}
+		if _, err := sketch.Containment(a, other); err == nil {
+			t.Error("Want: error comparing incompatible sketches")
+		}
+		if _, err := sketch.Distance(a, other); err == nil {
+			t.Error("Want: error comparing incompatible sketches")
+		}
+	}
+}
+
+func ExampleDistance() {
+	genome := random(test.Seed, 100000)
+	a, _ := sketch.NewBottomK(21, 1000)
+	b, _ := sketch.NewBottomK(21, 1000)
+	a.Add(immutable.New(genome))
+	b.Add(immutable.New(mutate(test.Seed, genome, 0.01)))
+
+	d, _ := sketch.Distance(a, b)
+	fmt.Printf("%.2f\n", d)
+	// Output: 0.01
+}
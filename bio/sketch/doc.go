@@ -0,0 +1,10 @@
+/*
+Package sketch estimates how alike collections of sequences are from MinHash sketches.
+
+A Sketch keeps a small, fixed sample of the hashes of the canonical k-mers of its
+sequences: the lowest hashes (bottom-k, as Mash does) or every hash below a fraction
+of the hash range (FracMinHash, as sourmash does). Sketches estimate the Jaccard
+similarity, Mash distance, and containment of the collections they were made from.
+Hashes are computed by this package, so only sketches made by it can be compared.
+*/
+package sketch
@@ -0,0 +1,96 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// magic begins every serialized Sketch
+const magic = "BSKT"
+
+// version is the version of the serialized format
+const version = 1
+
+// WriteTo writes the Sketch in a compact binary format: a header of the kind, k,
+// size or scale, and seed followed by the ascending hashes as varint deltas
+func (s *Sketch) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, 0, 32+len(s.hashes)*5)
+	buf = append(buf, magic...)
+	buf = append(buf, version, byte(s.kind), byte(s.k))
+	tmp := make([]byte, binary.MaxVarintLen64)
+	for _, v := range []uint64{s.param, s.seed, uint64(len(s.hashes))} {
+		buf = append(buf, tmp[:binary.PutUvarint(tmp, v)]...)
+	}
+	prev := uint64(0)
+	for _, h := range s.Hashes() {
+		buf = append(buf, tmp[:binary.PutUvarint(tmp, h-prev)]...)
+		prev = h
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// byteReader reads single bytes from a reader without buffering past them
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+// ReadByte reads the next byte
+func (b *byteReader) ReadByte() (byte, error) {
+	_, err := io.ReadFull(b.r, b.buf[:])
+	return b.buf[0], err
+}
+
+// ReadSketch reads a Sketch written by WriteTo, reading no further than its end
+// so sketches can be read one after another from the same reader
+func ReadSketch(r io.Reader) (*Sketch, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+	head := make([]byte, len(magic)+3)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return nil, err
+	}
+	if string(head[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not a sketch")
+	}
+	if head[len(magic)] != version {
+		return nil, fmt.Errorf("unknown sketch version: %d", head[len(magic)])
+	}
+	kind, k := Kind(head[len(magic)+1]), uint(head[len(magic)+2])
+	var vs [3]uint64
+	for i := range vs {
+		v, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		vs[i] = v
+	}
+
+	var s *Sketch
+	var err error
+	switch kind {
+	case BottomK:
+		s, err = NewBottomK(k, uint(vs[0]), SeedIs(vs[1]))
+	case Frac:
+		s, err = NewFrac(k, vs[0], SeedIs(vs[1]))
+	default:
+		return nil, fmt.Errorf("unknown sketch kind: %d", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	h := uint64(0)
+	for i := uint64(0); i < vs[2]; i++ {
+		d, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		h += d
+		s.add(h)
+	}
+	return s, nil
+}
@@ -0,0 +1,82 @@
+package sketch_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/sketch"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestWriteTo(t *testing.T) {
+	for _, mk := range []func() (*sketch.Sketch, error){
+		func() (*sketch.Sketch, error) { return sketch.NewBottomK(21, 1000, sketch.SeedIs(9)) },
+		func() (*sketch.Sketch, error) { return sketch.NewFrac(31, 50) },
+	} {
+		s, _ := mk()
+		sketchOf(t, s, random(test.Seed, 50000))
+		buf := new(bytes.Buffer)
+		n, err := s.WriteTo(buf)
+		if err != nil || n != int64(buf.Len()) {
+			t.Fatalf("failed to write: %d %v", n, err)
+		}
+		if perHash := float64(n) / float64(len(s.Hashes())); perHash > 9 {
+			t.Errorf("Want: compact hashes, Got: %.1f bytes each", perHash)
+		}
+		back, err := sketch.ReadSketch(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(back.Hashes(), s.Hashes()) || back.K() != s.K() ||
+			back.Kind() != s.Kind() || back.Size() != s.Size() || back.Scaled() != s.Scaled() {
+			t.Error("sketches should read back as written")
+		}
+		if j, err := sketch.Jaccard(s, back); err != nil || j != 1 {
+			t.Errorf("Want: sketches read back to be compatible, Got: %f %v", j, err)
+		}
+	}
+}
+
+func TestReadSketchConsecutive(t *testing.T) {
+	a, _ := sketch.NewBottomK(21, 100)
+	sketchOf(t, a, random(test.Seed, 1000))
+	b, _ := sketch.NewFrac(15, 10)
+	sketchOf(t, b, random(test.Seed+1, 1000))
+	buf := new(bytes.Buffer)
+	a.WriteTo(buf)
+	b.WriteTo(buf)
+	r := struct{ io.Reader }{buf}
+	for _, want := range []*sketch.Sketch{a, b} {
+		got, err := sketch.ReadSketch(r)
+		if err != nil || !reflect.DeepEqual(got.Hashes(), want.Hashes()) || got.Kind() != want.Kind() {
+			t.Fatalf("Want: each sketch read in turn, Got: %v", err)
+		}
+	}
+	if _, err := sketch.ReadSketch(r); err != io.EOF {
+		t.Errorf("Want: EOF after the last sketch, Got: %v", err)
+	}
+}
+
+func TestReadSketchErrors(t *testing.T) {
+	s, _ := sketch.NewBottomK(21, 10)
+	sketchOf(t, s, random(test.Seed, 100))
+	buf := new(bytes.Buffer)
+	s.WriteTo(buf)
+	good := buf.String()
+	for name, in := range map[string]string{
+		"Empty":     "",
+		"Magic":     "XXXX" + good[4:],
+		"Version":   good[:4] + "\x02" + good[5:],
+		"Kind":      good[:5] + "\x09" + good[6:],
+		"Truncated": good[:len(good)-1],
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := sketch.ReadSketch(strings.NewReader(in)); err == nil {
+				t.Error("Want: error")
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package sketch
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/kmer"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+// Kind is how a Sketch chooses the hashes it keeps
+type Kind byte
+
+const (
+	// BottomK keeps a fixed number of the lowest hashes
+	BottomK Kind = iota + 1
+
+	// Frac keeps every hash below a fraction of the hash range (FracMinHash)
+	Frac
+)
+
+// Sketch is a MinHash sketch of the canonical k-mers of some sequences
+type Sketch struct {
+	kind   Kind
+	k      uint
+	param  uint64
+	seed   uint64
+	hashes map[uint64]bool
+	bottom maxHeap
+}
+
+// Option is a setting of a Sketch
+type Option func(*Sketch)
+
+// SeedIs sets the seed of the hash (42 by default). Only sketches with the same
+// seed can be compared.
+func SeedIs(seed uint64) Option {
+	return func(s *Sketch) {
+		s.seed = seed
+	}
+}
+
+// NewBottomK generates a Sketch keeping the size lowest hashes of k-mers of k bases
+func NewBottomK(k, size uint, opts ...Option) (*Sketch, error) {
+	if size == 0 {
+		return nil, fmt.Errorf("bottom-k sketches must keep at least one hash")
+	}
+	return newSketch(BottomK, k, uint64(size), opts)
+}
+
+// NewFrac generates a Sketch keeping every hash of k-mers of k bases that is in
+// the lowest 1/scaled of the hash range
+func NewFrac(k uint, scaled uint64, opts ...Option) (*Sketch, error) {
+	if scaled == 0 {
+		return nil, fmt.Errorf("FracMinHash sketches must have a scale of at least 1")
+	}
+	return newSketch(Frac, k, scaled, opts)
+}
+
+// newSketch generates an empty Sketch
+func newSketch(kind Kind, k uint, param uint64, opts []Option) (*Sketch, error) {
+	if k == 0 || k > kmer.MaxK {
+		return nil, fmt.Errorf("k [%d] must be from 1 to %d", k, kmer.MaxK)
+	}
+	s := &Sketch{
+		kind:   kind,
+		k:      k,
+		param:  param,
+		seed:   42,
+		hashes: make(map[uint64]bool),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Kind is how the Sketch chooses the hashes it keeps
+func (s *Sketch) Kind() Kind {
+	return s.kind
+}
+
+// K is the length of the k-mers sketched
+func (s *Sketch) K() uint {
+	return s.k
+}
+
+// Size is the most hashes a BottomK Sketch keeps (0 for Frac)
+func (s *Sketch) Size() uint {
+	if s.kind != BottomK {
+		return 0
+	}
+	return uint(s.param)
+}
+
+// Scaled is the inverse of the fraction of hashes a Frac Sketch keeps (0 for BottomK)
+func (s *Sketch) Scaled() uint64 {
+	if s.kind != Frac {
+		return 0
+	}
+	return s.param
+}
+
+// hash scrambles a k-mer over the whole 64-bit range (the MurmurHash3 finalizer)
+func (s *Sketch) hash(x kmer.Kmer) uint64 {
+	h := uint64(x) ^ s.seed
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// Add sketches the k-mers of seq
+func (s *Sketch) Add(seq sequence.Interface) error {
+	it, err := kmer.NewIterator(seq, s.k)
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		s.add(s.hash(it.Canonical()))
+	}
+	return nil
+}
+
+// AddRecord sketches the k-mers of the sequence of a FASTA or FASTQ record
+func (s *Sketch) AddRecord(r fasta.Interface) error {
+	return s.Add(immutable.New(r.Sequence()))
+}
+
+// add keeps hash h if the Sketch chooses it
+func (s *Sketch) add(h uint64) {
+	if s.hashes[h] {
+		return
+	}
+	switch s.kind {
+	case Frac:
+		if h <= math.MaxUint64/s.param {
+			s.hashes[h] = true
+		}
+	case BottomK:
+		if uint64(len(s.bottom)) < s.param {
+			heap.Push(&s.bottom, h)
+			s.hashes[h] = true
+		} else if h < s.bottom[0] {
+			delete(s.hashes, s.bottom[0])
+			s.bottom[0] = h
+			heap.Fix(&s.bottom, 0)
+			s.hashes[h] = true
+		}
+	}
+}
+
+// Hashes are the hashes kept in ascending order
+func (s *Sketch) Hashes() []uint64 {
+	hs := make([]uint64, 0, len(s.hashes))
+	for h := range s.hashes {
+		hs = append(hs, h)
+	}
+	sort.Slice(hs, func(i, j int) bool {
+		return hs[i] < hs[j]
+	})
+	return hs
+}
+
+// Merge adds the hashes of another compatible Sketch, as if its sequences had been added
+func (s *Sketch) Merge(o *Sketch) error {
+	if err := s.compatible(o); err != nil {
+		return err
+	}
+	for h := range o.hashes {
+		s.add(h)
+	}
+	return nil
+}
+
+// compatible returns an error unless the sketches can be compared
+func (s *Sketch) compatible(o *Sketch) error {
+	if s.kind != o.kind || s.k != o.k || s.param != o.param || s.seed != o.seed {
+		return fmt.Errorf("sketches differ in kind, k, size or scale, or seed")
+	}
+	return nil
+}
+
+// maxHeap holds the bottom-k hashes with the highest on top
+type maxHeap []uint64
+
+func (m maxHeap) Len() int            { return len(m) }
+func (m maxHeap) Less(i, j int) bool  { return m[i] > m[j] }
+func (m maxHeap) Swap(i, j int)       { m[i], m[j] = m[j], m[i] }
+func (m *maxHeap) Push(x interface{}) { *m = append(*m, x.(uint64)) }
+func (m *maxHeap) Pop() interface{} {
+	old := *m
+	x := old[len(old)-1]
+	*m = old[:len(old)-1]
+	return x
+}
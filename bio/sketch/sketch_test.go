@@ -0,0 +1,122 @@
+package sketch_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/io/fasta/base"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/sketch"
+	"github.com/sembio/go/bio/test"
+)
+
+// random generates a random DNA sequence of n bases
+func random(seed int64, n uint) string {
+	return test.RandomStringFromRunes(seed, n, []rune("ACGT"))
+}
+
+// mutate substitutes a fraction rate of the bases of s
+func mutate(seed int64, s string, rate float64) string {
+	rng := rand.New(rand.NewSource(seed))
+	b := []byte(s)
+	for i := range b {
+		if rng.Float64() < rate {
+			b[i] = "ACGT"[(int(b[i])+1+rng.Intn(3))%4]
+		}
+	}
+	return string(b)
+}
+
+func sketchOf(t *testing.T, s *sketch.Sketch, seqs ...string) *sketch.Sketch {
+	for _, seq := range seqs {
+		if err := s.Add(immutable.New(seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestBottomK(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Bottom-k sketches keep the lowest hashes of all k-mers",
+		prop.ForAll(
+			func(n, size uint) bool {
+				seq := random(test.Seed+int64(n), n)
+				all, _ := sketch.NewFrac(11, 1)
+				bottom, _ := sketch.NewBottomK(11, size)
+				all.Add(immutable.New(seq))
+				bottom.Add(immutable.New(seq))
+				want := all.Hashes()
+				if uint(len(want)) > size {
+					want = want[:size]
+				}
+				return reflect.DeepEqual(bottom.Hashes(), want)
+			},
+			gen.UIntRange(0, 500),
+			gen.UIntRange(1, 200),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestFrac(t *testing.T) {
+	s, _ := sketch.NewFrac(21, 100)
+	sketchOf(t, s, random(test.Seed, 100000))
+	if n := len(s.Hashes()); n < 800 || n > 1200 {
+		t.Errorf("Want: about 1000 of 100000 hashes kept, Got: %d", n)
+	}
+	if s.Scaled() != 100 || s.Size() != 0 || s.Kind() != sketch.Frac || s.K() != 21 {
+		t.Errorf("unexpected settings: %d %d %d %d", s.Scaled(), s.Size(), s.Kind(), s.K())
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, b := random(test.Seed, 2000), random(test.Seed+1, 2000)
+	for _, kind := range []func() (*sketch.Sketch, error){
+		func() (*sketch.Sketch, error) { return sketch.NewBottomK(15, 100) },
+		func() (*sketch.Sketch, error) { return sketch.NewFrac(15, 10) },
+	} {
+		both, _ := kind()
+		sa, _ := kind()
+		sb, _ := kind()
+		sketchOf(t, both, a, b)
+		sketchOf(t, sa, a)
+		sketchOf(t, sb, b)
+		if err := sa.Merge(sb); err != nil || !reflect.DeepEqual(sa.Hashes(), both.Hashes()) {
+			t.Errorf("merged sketches should be the sketch of both: %v", err)
+		}
+	}
+	other, _ := sketch.NewBottomK(15, 100, sketch.SeedIs(7))
+	mine, _ := sketch.NewBottomK(15, 100)
+	if err := mine.Merge(other); err == nil {
+		t.Error("Want: error merging sketches of different seeds")
+	}
+}
+
+func TestAddRecord(t *testing.T) {
+	seq := random(test.Seed, 500)
+	a, _ := sketch.NewBottomK(21, 50)
+	b, _ := sketch.NewBottomK(21, 50)
+	sketchOf(t, a, seq)
+	if err := b.AddRecord(base.New("r", immutable.New(seq))); err != nil || !reflect.DeepEqual(a.Hashes(), b.Hashes()) {
+		t.Errorf("records should sketch as their sequences: %v", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := sketch.NewBottomK(21, 0); err == nil {
+		t.Error("Want: error for a size of 0")
+	}
+	if _, err := sketch.NewFrac(21, 0); err == nil {
+		t.Error("Want: error for a scale of 0")
+	}
+	if _, err := sketch.NewFrac(33, 10); err == nil {
+		t.Error("Want: error for k of 33")
+	}
+}
@@ -0,0 +1,7 @@
+/*
+Package codon summarizes how codons are used by coding sequences.
+Usage counts are the basis for relative synonymous codon usage (RSCU),
+the Codon Adaptation Index (CAI), and the Kazusa/CUTG codon usage format.
+See https://www.kazusa.or.jp/codon/ for the format and published tables.
+*/
+package codon
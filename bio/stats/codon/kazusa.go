@@ -0,0 +1,73 @@
+package codon
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// kazusaEntry matches a single codon entry of either Kazusa layout:
+//
+//	UUU 17.6(714298)
+//	UUU F 0.46 17.6 (714298)
+var kazusaEntry = regexp.MustCompile(
+	`([ACGTU]{3})\s+(?:[A-Z*]\s+[0-9.]+\s+)?[0-9.]+\s*\(\s*([0-9]+)\s*\)`,
+)
+
+// ReadKazusa reads a codon usage table in the Kazusa/CUTG format.
+// Both the plain layout and the layout with amino acids and fractions are understood.
+// Codons are stored as DNA, so "UUU" is counted as "TTT".
+func ReadKazusa(r io.Reader) (*Usage, error) {
+	u := NewUsage()
+	br := bufio.NewScanner(r)
+	br.Split(bufio.ScanLines)
+	for br.Scan() {
+		for _, m := range kazusaEntry.FindAllStringSubmatch(strings.ToUpper(br.Text()), -1) {
+			n, err := strconv.ParseUint(m[2], 10, 0)
+			if err != nil {
+				return u, err
+			}
+			u.counts[strings.Replace(m[1], "U", "T", -1)] += uint(n)
+		}
+	}
+	if err := br.Err(); err != nil {
+		return u, err
+	}
+	if len(u.counts) == 0 {
+		return u, fmt.Errorf("no codon usage entries found")
+	}
+	return u, nil
+}
+
+// WriteKazusa writes a codon usage table in the plain Kazusa/CUTG format:
+// codons in RNA with their frequency per thousand and count.
+func WriteKazusa(w io.Writer, u *Usage) error {
+	const order = "UCAG"
+	total := float64(u.Total())
+	out := new(strings.Builder)
+	for i := range order {
+		if i != 0 {
+			out.WriteString("\n")
+		}
+		for k := range order {
+			for j := range order {
+				rna := string([]byte{order[i], order[j], order[k]})
+				n := u.Count(strings.Replace(rna, "U", "T", -1))
+				perThousand := 0.0
+				if total != 0 {
+					perThousand = 1000 * float64(n) / total
+				}
+				if j != 0 {
+					out.WriteString("  ")
+				}
+				fmt.Fprintf(out, "%s %4.1f(%6d)", rna, perThousand, n)
+			}
+			out.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
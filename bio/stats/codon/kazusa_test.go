@@ -0,0 +1,103 @@
+package codon_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/codon"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestReadKazusa(t *testing.T) {
+	t.Run("Plain layout", func(t *testing.T) {
+		in := "UUU 17.6(714298)  UCU 15.2(618711)  UAU 12.2(495699)  UGU 10.6(430311)\n" +
+			"UUC 20.3(824692)  UCC 17.7(718892)  UAC 15.3(622407)  UGC 12.6(513028)\n"
+		u, err := codon.ReadKazusa(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if u.Count("TTT") != 714298 || u.Count("TGC") != 513028 || u.Total() != 4938038 {
+			t.Errorf("counts did not match input")
+		}
+	})
+	t.Run("Amino acid layout", func(t *testing.T) {
+		in := "UUU F 0.46 17.6 (714298)  UCU S 0.19 15.2 (618711)\n"
+		u, err := codon.ReadKazusa(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if u.Count("TTT") != 714298 || u.Count("TCT") != 618711 {
+			t.Errorf("counts did not match input")
+		}
+	})
+	t.Run("No entries errors", func(t *testing.T) {
+		if _, err := codon.ReadKazusa(strings.NewReader("nothing here\n")); err == nil {
+			t.Error("input without entries should error")
+		}
+	})
+}
+
+func TestKazusaRoundTrip(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("ReadKazusa(WriteKazusa(u)) has the same counts",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				seq, _ := immutable.NewDna(s)
+				want := codon.NewUsage()
+				want.Add(seq, 1)
+				b := new(bytes.Buffer)
+				if err := codon.WriteKazusa(b, want); err != nil {
+					return false
+				}
+				got, err := codon.ReadKazusa(b)
+				if err != nil {
+					return false
+				}
+				for c, f := range want.Frequencies() {
+					if got.Frequencies()[c] != f {
+						return false
+					}
+				}
+				return got.Total() == want.Total()
+			},
+			gen.UIntRange(3, sequence.TestableLength),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func ExampleReadKazusa() {
+	u, err := codon.ReadKazusa(strings.NewReader("UUU 17.6(714298)  UCU 15.2(618711)"))
+
+	fmt.Println(u.Count("TTT"), u.Count("TCT"), err)
+	// Output:
+	// 714298 618711 <nil>
+}
+
+// Only the first row of the table is shown
+func ExampleWriteKazusa() {
+	seq, _ := immutable.NewDna("TTTTTTTCTTAA")
+	u := codon.NewUsage()
+	u.Add(seq, 1)
+	b := new(bytes.Buffer)
+	codon.WriteKazusa(b, u)
+
+	fmt.Println(strings.SplitN(b.String(), "\n", 2)[0])
+	// Output:
+	// UUU 500.0(     2)  UCU 250.0(     1)  UAU  0.0(     0)  UGU  0.0(     0)
+}
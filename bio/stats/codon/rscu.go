@@ -0,0 +1,95 @@
+package codon
+
+import (
+	"fmt"
+	"math"
+
+	gencode "github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// RSCU is the relative synonymous codon usage of each codon in table t.
+// A codon's RSCU is its count divided by the mean count of its synonymous codons,
+// so 1 means no bias, above 1 means preferred, and below 1 means avoided.
+// Stop codons are treated as synonymous with one another.
+// Codons whose amino acid was never seen have no RSCU.
+func RSCU(u *Usage, t gencode.Interface) map[string]float64 {
+	rscu := make(map[string]float64, 64)
+	for _, cdns := range families(t) {
+		total := uint(0)
+		for _, c := range cdns {
+			total += u.Count(c)
+		}
+		if total == 0 {
+			continue
+		}
+		mean := float64(total) / float64(len(cdns))
+		for _, c := range cdns {
+			rscu[c] = float64(u.Count(c)) / mean
+		}
+	}
+	return rscu
+}
+
+// Weights is the relative adaptiveness of each codon in table t.
+// A codon's weight is its count divided by the count of the most used synonymous codon.
+// Following Sharp and Li (1987), unseen codons are given a count of 0.5 so no weight is zero.
+// Codons whose amino acid was never seen have no weight.
+func Weights(u *Usage, t gencode.Interface) map[string]float64 {
+	w := make(map[string]float64, 64)
+	for _, cdns := range families(t) {
+		max := uint(0)
+		for _, c := range cdns {
+			if u.Count(c) > max {
+				max = u.Count(c)
+			}
+		}
+		if max == 0 {
+			continue
+		}
+		for _, c := range cdns {
+			n := float64(u.Count(c))
+			if n == 0 {
+				n = 0.5
+			}
+			w[c] = n / float64(max)
+		}
+	}
+	return w
+}
+
+// CAI is the Codon Adaptation Index of coding sequence s (read in frame 1)
+// against the codon usage of a reference set of highly expressed genes.
+// CAI is the geometric mean of the reference Weights of the codons in s,
+// leaving out stop codons and amino acids with a single codon (Sharp and Li, 1987).
+// An error is returned if s has no codons that inform the index.
+func CAI(s sequence.Interface, ref *Usage, t gencode.Interface) (float64, error) {
+	w := Weights(ref, t)
+	informative := make(map[string]bool, 64)
+	for aa, cdns := range families(t) {
+		for _, c := range cdns {
+			informative[c] = aa != '*' && len(cdns) > 1
+		}
+	}
+
+	u := NewUsage()
+	if err := u.Add(s, 1); err != nil {
+		return 0, err
+	}
+	sum, n := 0.0, uint(0)
+	for c, count := range u.counts {
+		if !informative[c] {
+			continue
+		}
+		weight, ok := w[c]
+		if !ok {
+			return 0, fmt.Errorf("reference usage has no synonymous codons for %q", c)
+		}
+		sum += float64(count) * math.Log(weight)
+		n += count
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("no codons informative of adaptation when using %s", t)
+	}
+	return math.Exp(sum / float64(n)), nil
+}
@@ -0,0 +1,102 @@
+package codon_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	gencode "github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/codon"
+)
+
+func TestRSCU(t *testing.T) {
+	// Leu: CTG x4, TTA x2; Met: ATG x1
+	seq, _ := immutable.NewDna("CTGCTGCTGCTGTTATTAATG")
+	u := codon.NewUsage()
+	u.Add(seq, 1)
+	rscu := codon.RSCU(u, gencode.Standard{})
+	tt := []struct {
+		codon string
+		want  float64
+		ok    bool
+	}{
+		{"CTG", 4, true},
+		{"TTA", 2, true},
+		{"CTT", 0, true},
+		{"ATG", 1, true},
+		{"GGG", 0, false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.codon, func(t *testing.T) {
+			got, ok := rscu[tc.codon]
+			if ok != tc.ok || math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("Want: %v %v, Got: %v %v", tc.want, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestRSCUTranslatedStops(t *testing.T) {
+	// Blastocrithidia reads its stop codons TAA and TAG as Glu
+	seq, _ := immutable.NewDna("TAAGAAGAAGAA")
+	u := codon.NewUsage()
+	u.Add(seq, 1)
+	rscu := codon.RSCU(u, gencode.Blastocrithidia{})
+	for c, want := range map[string]float64{"TAA": 1, "TAG": 0, "GAA": 3, "GAG": 0} {
+		if got := rscu[c]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s Want: %v, Got: %v", c, want, got)
+		}
+	}
+}
+
+func TestCAI(t *testing.T) {
+	ref := codon.NewUsage()
+	refSeq, _ := immutable.NewDna("CTGCTGCTGCTGTTATTAAAAAAG")
+	ref.Add(refSeq, 1)
+
+	t.Run("Only preferred codons is one", func(t *testing.T) {
+		seq, _ := immutable.NewDna("ATGCTGAAACTGTAA")
+		got, err := codon.CAI(seq, ref, gencode.Standard{})
+		if err != nil || math.Abs(got-1) > 1e-9 {
+			t.Errorf("Want: 1 <nil>, Got: %v %v", got, err)
+		}
+	})
+	t.Run("Is geometric mean of weights", func(t *testing.T) {
+		seq, _ := immutable.NewDna("CTGTTA")
+		got, _ := codon.CAI(seq, ref, gencode.Standard{})
+		if want := math.Sqrt(1 * 0.5); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Want: %v, Got: %v", want, got)
+		}
+	})
+	t.Run("Unseen synonymous codon is given half a count", func(t *testing.T) {
+		seq, _ := immutable.NewDna("CTT")
+		got, _ := codon.CAI(seq, ref, gencode.Standard{})
+		if want := 0.5 / 4; math.Abs(got-want) > 1e-9 {
+			t.Errorf("Want: %v, Got: %v", want, got)
+		}
+	})
+	t.Run("Uninformative sequence errors", func(t *testing.T) {
+		seq, _ := immutable.NewDna("ATGTGGTAA")
+		if _, err := codon.CAI(seq, ref, gencode.Standard{}); err == nil {
+			t.Error("sequence of only Met, Trp, and stop should error")
+		}
+	})
+	t.Run("Amino acid missing from reference errors", func(t *testing.T) {
+		seq, _ := immutable.NewDna("GGG")
+		if _, err := codon.CAI(seq, ref, gencode.Standard{}); err == nil {
+			t.Error("Gly missing from reference should error")
+		}
+	})
+}
+
+func ExampleRSCU() {
+	seq, _ := immutable.NewDna("AAAAAAAAG")
+	u := codon.NewUsage()
+	u.Add(seq, 1)
+	rscu := codon.RSCU(u, gencode.Standard{})
+
+	fmt.Printf("%.2f %.2f", rscu["AAA"], rscu["AAG"])
+	// Output:
+	// 1.33 0.67
+}
@@ -0,0 +1,114 @@
+package codon
+
+import (
+	"fmt"
+	"strings"
+
+	gencode "github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// Usage counts how often each codon is seen in coding sequences
+type Usage struct {
+	counts map[string]uint
+}
+
+// NewUsage generates an empty Usage
+func NewUsage() *Usage {
+	return &Usage{
+		counts: make(map[string]uint, 64),
+	}
+}
+
+// Add counts the codons of s read in the given frame.
+// Frames 1, 2, and 3 start at the first, second, and third position of s while
+// frames -1, -2, and -3 do the same on the reverse complement of s.
+// RNA is counted as its DNA equivalent and codons with letters other than
+// A, C, G, T are skipped.
+func (u *Usage) Add(s sequence.Interface, frame int) error {
+	if frame == 0 || frame < -3 || 3 < frame {
+		return fmt.Errorf("requested impossible frame [%d]", frame)
+	}
+	if frame < 0 {
+		rc, ok := s.(sequence.RevComper)
+		if !ok {
+			return fmt.Errorf("frame %d requires a sequence that can reverse-complement", frame)
+		}
+		var err error
+		if s, err = rc.RevComp(); err != nil {
+			return err
+		}
+		frame = -frame
+	}
+	seq, err := s.Range(0, s.Length())
+	if err != nil {
+		return err
+	}
+	seq = strings.Replace(strings.ToUpper(seq), "U", "T", -1)
+	for i := frame - 1; i+3 <= len(seq); i += 3 {
+		if cdn := seq[i : i+3]; strings.Trim(cdn, "ACGT") == "" {
+			u.counts[cdn]++
+		}
+	}
+	return nil
+}
+
+// Count is the number of times codon c has been seen
+func (u *Usage) Count(c string) uint {
+	return u.counts[c]
+}
+
+// Total is the number of codons seen
+func (u *Usage) Total() uint {
+	total := uint(0)
+	for _, n := range u.counts {
+		total += n
+	}
+	return total
+}
+
+// Frequencies is the fraction of all codons seen that were each codon.
+// An empty Usage has no frequencies.
+func (u *Usage) Frequencies() map[string]float64 {
+	freqs := make(map[string]float64, len(u.counts))
+	total := float64(u.Total())
+	for c, n := range u.counts {
+		freqs[c] = float64(n) / total
+	}
+	return freqs
+}
+
+// MostUsed is the codon of cdns seen most often, with ties going to the earliest in cdns
+func (u *Usage) MostUsed(cdns []string) string {
+	best := ""
+	for i, c := range cdns {
+		if i == 0 || u.counts[c] > u.counts[best] {
+			best = c
+		}
+	}
+	return best
+}
+
+// Merge adds all counts from v into u
+func (u *Usage) Merge(v *Usage) {
+	for c, n := range v.counts {
+		u.counts[c] += n
+	}
+}
+
+// families groups the codons of a table by amino acid, with stop codons grouped under '*'.
+// Stop codons that the table also translates, as in Blastocrithidia, stay with their amino acid.
+func families(t gencode.Interface) map[byte][]string {
+	fams := make(map[byte][]string)
+	for _, c := range gencode.Triplets() {
+		if aa, ok := t.Translate(c); ok {
+			fams[aa] = append(fams[aa], c)
+		}
+	}
+	for _, c := range t.StopCodons() {
+		if _, ok := t.Translate(c); !ok {
+			fams['*'] = append(fams['*'], c)
+		}
+	}
+	return fams
+}
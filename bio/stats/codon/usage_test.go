@@ -0,0 +1,136 @@
+package codon_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/codon"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestUsageAdd(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Frame 1 counts a third of the Dna length",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				seq, _ := immutable.NewDna(s)
+				u := codon.NewUsage()
+				u.Add(seq, 1)
+				return u.Total() == n/3
+			},
+			gen.UIntRange(1, sequence.TestableLength),
+		),
+	)
+	properties.Property("Frequencies sum to one",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				seq, _ := immutable.NewDna(s)
+				u := codon.NewUsage()
+				u.Add(seq, 2)
+				sum := 0.0
+				for _, f := range u.Frequencies() {
+					sum += f
+				}
+				return 0.999999 < sum && sum < 1.000001
+			},
+			gen.UIntRange(4, sequence.TestableLength),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestUsageFrames(t *testing.T) {
+	seq, _ := immutable.NewDna("ATGAAACCCTAG")
+	tt := []struct {
+		frame int
+		codon string
+		count uint
+		total uint
+	}{
+		{1, "ATG", 1, 4},
+		{2, "TGA", 1, 3},
+		{3, "GAA", 1, 3},
+		{-1, "CTA", 1, 4},
+		{-2, "TAG", 1, 3},
+		{-3, "AGG", 1, 3},
+	}
+	for _, tc := range tt {
+		t.Run(fmt.Sprintf("Frame %d", tc.frame), func(t *testing.T) {
+			u := codon.NewUsage()
+			if err := u.Add(seq, tc.frame); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.Count(tc.codon) != tc.count || u.Total() != tc.total {
+				t.Errorf("Want: %d of %q in %d, Got: %d in %d",
+					tc.count, tc.codon, tc.total, u.Count(tc.codon), u.Total())
+			}
+		})
+	}
+	t.Run("Frame 0 errors", func(t *testing.T) {
+		if err := codon.NewUsage().Add(seq, 0); err == nil {
+			t.Error("frame 0 should error")
+		}
+	})
+	t.Run("Reverse frame requires RevComper", func(t *testing.T) {
+		if err := codon.NewUsage().Add(immutable.New("ATG"), -1); err == nil {
+			t.Error("reverse frame of sequence without RevComp should error")
+		}
+	})
+}
+
+func TestUsageSkipsAmbiguous(t *testing.T) {
+	seq, _ := immutable.NewDnaIupac("ATGNNN---ATG")
+	u := codon.NewUsage()
+	u.Add(seq, 1)
+	if u.Total() != 2 || u.Count("ATG") != 2 {
+		t.Errorf("Want: 2 ATG, Got: %d ATG of %d", u.Count("ATG"), u.Total())
+	}
+}
+
+func TestUsageMostUsed(t *testing.T) {
+	seq, _ := immutable.NewDna("CTGCTGTTATTAAAA")
+	u := codon.NewUsage()
+	u.Add(seq, 1)
+	tt := []struct {
+		cdns []string
+		want string
+	}{
+		{[]string{"TTA", "CTG", "CTT"}, "TTA"},
+		{[]string{"CTT", "AAA", "CTA"}, "AAA"},
+		{[]string{"CTT", "CTA"}, "CTT"},
+		{nil, ""},
+	}
+	for _, tc := range tt {
+		if got := u.MostUsed(tc.cdns); got != tc.want {
+			t.Errorf("%v Want: %q, Got: %q", tc.cdns, tc.want, got)
+		}
+	}
+}
+
+func ExampleUsage_Add() {
+	seq, _ := immutable.NewRna("AUGAUGUAA")
+	u := codon.NewUsage()
+	err := u.Add(seq, 1)
+
+	fmt.Println(u.Count("ATG"), u.Count("TAA"), err)
+	// Output:
+	// 2 1 <nil>
+}
@@ -0,0 +1,4 @@
+/*
+Package stats is a collection of summary statistics computed over biological data
+*/
+package stats
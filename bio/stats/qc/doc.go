@@ -0,0 +1,6 @@
+/*
+Package qc collects quality-control statistics over FASTQ or FASTA reads,
+much like FastQC. A Collector summarizes reads as they stream past into a
+Report, which serializes to JSON and renders as plain text or HTML.
+*/
+package qc
@@ -0,0 +1,124 @@
+package qc
+
+import (
+	"math"
+	"strings"
+
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/io/fastq"
+)
+
+const (
+	// MaxTracked is the most distinct sequences tracked for duplication and
+	// overrepresentation, after which only reads of sequences already seen are counted
+	MaxTracked = 100000
+
+	// Overrepresented is the fraction of reads above which a sequence is overrepresented
+	Overrepresented = 0.001
+
+	// maxPhred is the highest Phred score that can be written in a quality line
+	maxPhred = 93
+)
+
+// Collector accumulates the statistics of reads for a Report
+type Collector struct {
+	enc quality.Encoding
+
+	reads, bases, gc, acgt uint
+	qualities              uint
+	positionQuality        [][maxPhred + 1]uint
+	sequenceQuality        [maxPhred + 1]uint
+	composition            []map[byte]uint
+	gcDistribution         [101]uint
+	lengths                map[int]uint
+	tracked                map[string]uint
+	trackedReads           uint
+}
+
+// New generates a Collector for reads with qualities in Encoding e
+func New(e quality.Encoding) *Collector {
+	return &Collector{
+		enc:             e,
+		positionQuality: make([][maxPhred + 1]uint, 0),
+		composition:     make([]map[byte]uint, 0),
+		lengths:         make(map[int]uint),
+		tracked:         make(map[string]uint),
+	}
+}
+
+// Add collects the statistics of a read. Quality statistics are only collected
+// from reads that are also a fastq.Interface.
+func (c *Collector) Add(r fasta.Interface) error {
+	seq := strings.ToUpper(r.Sequence())
+	if q, ok := r.(fastq.Interface); ok {
+		if err := c.addQuality(q.Quality()); err != nil {
+			return err
+		}
+	}
+	c.reads++
+	c.bases += uint(len(seq))
+	c.lengths[len(seq)]++
+
+	for len(c.composition) < len(seq) {
+		c.composition = append(c.composition, make(map[byte]uint))
+	}
+	gc, acgt := uint(0), uint(0)
+	for i := 0; i < len(seq); i++ {
+		c.composition[i][seq[i]]++
+		switch seq[i] {
+		case 'G', 'C':
+			gc++
+			acgt++
+		case 'A', 'T', 'U':
+			acgt++
+		}
+	}
+	c.gc += gc
+	c.acgt += acgt
+	if acgt != 0 {
+		c.gcDistribution[int(math.Round(100*float64(gc)/float64(acgt)))]++
+	}
+
+	key := seq
+	if len(key) > 75 {
+		key = key[:50]
+	}
+	if _, ok := c.tracked[key]; ok || len(c.tracked) < MaxTracked {
+		c.tracked[key]++
+		c.trackedReads++
+	}
+	return nil
+}
+
+// addQuality collects the statistics of a quality line
+func (c *Collector) addQuality(q string) error {
+	scores, err := quality.Decode(q, c.enc)
+	if err != nil {
+		return err
+	}
+	phred := scores.Phred()
+	for len(c.positionQuality) < len(phred) {
+		c.positionQuality = append(c.positionQuality, [maxPhred + 1]uint{})
+	}
+	for i, p := range phred {
+		c.positionQuality[i][clamp(p)]++
+	}
+	if len(phred) != 0 {
+		c.sequenceQuality[clamp(scores.Mean())]++
+	}
+	c.qualities++
+	return nil
+}
+
+// clamp rounds a Phred score to the nearest score that can be written
+func clamp(p float64) int {
+	switch r := int(math.Round(p)); {
+	case r < 0:
+		return 0
+	case r > maxPhred:
+		return maxPhred
+	default:
+		return r
+	}
+}
@@ -0,0 +1,168 @@
+package qc_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/quality"
+	"github.com/sembio/go/bio/io/fasta"
+	"github.com/sembio/go/bio/io/fasta/base"
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/qc"
+	"github.com/sembio/go/bio/test"
+)
+
+func collect(t *testing.T, recs ...fasta.Interface) *qc.Report {
+	c := qc.New(quality.Illumina18)
+	for _, r := range recs {
+		if err := c.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c.Report()
+}
+
+func TestReport(t *testing.T) {
+	r := collect(t,
+		fastq.New("r1", "II#", immutable.New("ACG")),
+		fastq.New("r2", "I5", immutable.New("GN")),
+		fastq.New("r3", "II#", immutable.New("ACG")),
+	)
+	if r.Reads != 3 || r.Bases != 8 || r.Encoding != quality.Illumina18.String() {
+		t.Errorf("Want: 3 reads of 8 bases, Got: %d %d %q", r.Reads, r.Bases, r.Encoding)
+	}
+	if want := 100 * 5.0 / 7; math.Abs(r.GC-want) > 1e-9 {
+		t.Errorf("Want: GC %f, Got: %f", want, r.GC)
+	}
+	wantQ := []qc.PositionQuality{
+		{Position: 1, Mean: 40, Median: 40, LowerQuartile: 40, UpperQuartile: 40, Percentile10: 40, Percentile90: 40},
+		{Position: 2, Mean: 100.0 / 3, Median: 40, LowerQuartile: 20, UpperQuartile: 40, Percentile10: 20, Percentile90: 40},
+		{Position: 3, Mean: 2, Median: 2, LowerQuartile: 2, UpperQuartile: 2, Percentile10: 2, Percentile90: 2},
+	}
+	if !reflect.DeepEqual(r.PositionQuality, wantQ) {
+		t.Errorf("Want: %v, Got: %v", wantQ, r.PositionQuality)
+	}
+	if want := []qc.Count{{Value: 27, Count: 2}, {Value: 30, Count: 1}}; !reflect.DeepEqual(r.SequenceQuality, want) {
+		t.Errorf("Want: %v, Got: %v", want, r.SequenceQuality)
+	}
+	if want := []float64{0, 100.0 / 3, 0}; !reflect.DeepEqual(r.NContent, want) {
+		t.Errorf("Want: %v, Got: %v", want, r.NContent)
+	}
+	if want := []qc.Count{{Value: 67, Count: 2}, {Value: 100, Count: 1}}; !reflect.DeepEqual(r.GCDistribution, want) {
+		t.Errorf("Want: %v, Got: %v", want, r.GCDistribution)
+	}
+	if want := []qc.Count{{Value: 2, Count: 1}, {Value: 3, Count: 2}}; !reflect.DeepEqual(r.Lengths, want) {
+		t.Errorf("Want: %v, Got: %v", want, r.Lengths)
+	}
+	if math.Abs(r.Duplication.Remaining-200.0/3) > 1e-9 ||
+		r.Duplication.Levels[0].Percent != 100.0/3 || r.Duplication.Levels[1].Percent != 200.0/3 {
+		t.Errorf("Want: 66.67%% remaining, Got: %v", r.Duplication)
+	}
+	want := []qc.Sequence{{Sequence: "ACG", Count: 2, Percent: 200.0 / 3}, {Sequence: "GN", Count: 1, Percent: 100.0 / 3}}
+	if !reflect.DeepEqual(r.Overrepresented, want) {
+		t.Errorf("Want: %v, Got: %v", want, r.Overrepresented)
+	}
+}
+
+func TestReportFasta(t *testing.T) {
+	r := collect(t, base.New("r1", immutable.New("acgt")))
+	if r.Encoding != "" || len(r.PositionQuality) != 0 || len(r.SequenceQuality) != 0 {
+		t.Errorf("FASTA reads should have no quality statistics, Got: %+v", r)
+	}
+	if r.BaseContent[0].A != 100 || r.GC != 50 {
+		t.Errorf("Want: upper-cased bases, Got: %v %f", r.BaseContent, r.GC)
+	}
+}
+
+func TestAddError(t *testing.T) {
+	c := qc.New(quality.Illumina18)
+	if err := c.Add(fastq.New("r1", "I~", immutable.New("AC"))); err == nil {
+		t.Error("qualities outside of the encoding should error")
+	}
+	if r := c.Report(); r.Reads != 0 {
+		t.Errorf("reads that error should not be counted, Got: %d", r.Reads)
+	}
+}
+
+func TestReportGenerated(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Reports account for every read and base",
+		prop.ForAll(
+			func(n uint) bool {
+				recs, err := fastq.ReadMulti(
+					bytes.NewReader(fastq.TestGenMultiFastq(test.Seed, n, 10, hashmap.NewDna())),
+					func(s string) (sequence.Interface, error) {
+						return immutable.NewDna(s)
+					},
+				)
+				if err != nil {
+					return false
+				}
+				c := qc.New(quality.Illumina18)
+				bases := uint(0)
+				for _, rec := range recs {
+					if c.Add(rec) != nil {
+						return false
+					}
+					bases += uint(len(rec.Sequence()))
+				}
+				r := c.Report()
+				reads := uint(0)
+				for _, l := range r.Lengths {
+					reads += l.Count
+				}
+				for _, b := range r.BaseContent {
+					if math.Abs(b.A+b.C+b.G+b.T+b.N-100) > 1e-9 {
+						return false
+					}
+				}
+				for _, q := range r.PositionQuality {
+					if q.Percentile10 > q.LowerQuartile || q.LowerQuartile > q.Median ||
+						q.Median > q.UpperQuartile || q.UpperQuartile > q.Percentile90 {
+						return false
+					}
+				}
+				return r.Reads == uint(len(recs)) && reads == r.Reads && r.Bases == bases
+			},
+			gen.UIntRange(2, 300),
+		),
+	)
+	properties.TestingRun(t)
+}
+
+func TestReportJSON(t *testing.T) {
+	r := collect(t, fastq.New("r1", "II#", immutable.New("ACG")))
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back := new(qc.Report)
+	if err := json.Unmarshal(b, back); err != nil || !reflect.DeepEqual(r, back) {
+		t.Errorf("Want: %+v, Got: %+v %v", r, back, err)
+	}
+}
+
+func ExampleCollector_Report() {
+	c := qc.New(quality.Illumina18)
+	c.Add(fastq.New("r1", "II5#", immutable.New("ACGT")))
+	c.Add(fastq.New("r2", "IIII", immutable.New("GGCN")))
+	r := c.Report()
+
+	fmt.Printf("%d reads, %.1f%% GC\n", r.Reads, r.GC)
+	fmt.Println(r.PositionQuality[3].Mean, r.NContent[3])
+	// Output:
+	// 2 reads, 71.4% GC
+	// 21 50
+}
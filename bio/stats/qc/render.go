@@ -0,0 +1,108 @@
+package qc
+
+import (
+	html "html/template"
+	"io"
+	text "text/template"
+)
+
+const textReport = `Reads	{{.Reads}}
+Bases	{{.Bases}}
+{{if .Encoding}}Encoding	{{.Encoding}}
+{{end}}GC	{{printf "%.2f" .GC}}
+{{if .PositionQuality}}
+## Per position quality
+position	mean	median	lower_quartile	upper_quartile	percentile_10	percentile_90
+{{range .PositionQuality}}{{.Position}}	{{printf "%.2f" .Mean}}	{{.Median}}	{{.LowerQuartile}}	{{.UpperQuartile}}	{{.Percentile10}}	{{.Percentile90}}
+{{end}}
+## Per sequence quality
+quality	reads
+{{range .SequenceQuality}}{{.Value}}	{{.Count}}
+{{end}}{{end}}
+## Per base content
+position	a	c	g	t	n
+{{range .BaseContent}}{{.Position}}	{{printf "%.2f" .A}}	{{printf "%.2f" .C}}	{{printf "%.2f" .G}}	{{printf "%.2f" .T}}	{{printf "%.2f" .N}}
+{{end}}
+## GC distribution
+gc	reads
+{{range .GCDistribution}}{{.Value}}	{{.Count}}
+{{end}}
+## Length distribution
+length	reads
+{{range .Lengths}}{{.Value}}	{{.Count}}
+{{end}}
+## Duplication
+remaining	{{printf "%.2f" .Duplication.Remaining}}
+level	percent
+{{range .Duplication.Levels}}{{.Level}}	{{printf "%.2f" .Percent}}
+{{end}}
+## Overrepresented sequences
+sequence	count	percent
+{{range .Overrepresented}}{{.Sequence}}	{{.Count}}	{{printf "%.2f" .Percent}}
+{{end}}`
+
+const htmlReport = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>QC report</title></head>
+<body>
+<h1>QC report</h1>
+<table>
+<tr><th>Reads</th><td>{{.Reads}}</td></tr>
+<tr><th>Bases</th><td>{{.Bases}}</td></tr>
+{{if .Encoding}}<tr><th>Encoding</th><td>{{.Encoding}}</td></tr>
+{{end}}<tr><th>GC</th><td>{{printf "%.2f" .GC}}</td></tr>
+</table>
+{{if .PositionQuality}}<h2>Per position quality</h2>
+<table>
+<tr><th>Position</th><th>Mean</th><th>Median</th><th>Lower quartile</th><th>Upper quartile</th><th>10th percentile</th><th>90th percentile</th></tr>
+{{range .PositionQuality}}<tr><td>{{.Position}}</td><td>{{printf "%.2f" .Mean}}</td><td>{{.Median}}</td><td>{{.LowerQuartile}}</td><td>{{.UpperQuartile}}</td><td>{{.Percentile10}}</td><td>{{.Percentile90}}</td></tr>
+{{end}}</table>
+<h2>Per sequence quality</h2>
+<table>
+<tr><th>Quality</th><th>Reads</th></tr>
+{{range .SequenceQuality}}<tr><td>{{.Value}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+{{end}}<h2>Per base content</h2>
+<table>
+<tr><th>Position</th><th>A</th><th>C</th><th>G</th><th>T</th><th>N</th></tr>
+{{range .BaseContent}}<tr><td>{{.Position}}</td><td>{{printf "%.2f" .A}}</td><td>{{printf "%.2f" .C}}</td><td>{{printf "%.2f" .G}}</td><td>{{printf "%.2f" .T}}</td><td>{{printf "%.2f" .N}}</td></tr>
+{{end}}</table>
+<h2>GC distribution</h2>
+<table>
+<tr><th>GC</th><th>Reads</th></tr>
+{{range .GCDistribution}}<tr><td>{{.Value}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+<h2>Length distribution</h2>
+<table>
+<tr><th>Length</th><th>Reads</th></tr>
+{{range .Lengths}}<tr><td>{{.Value}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>
+<h2>Duplication</h2>
+<p>Remaining after deduplication: {{printf "%.2f" .Duplication.Remaining}}%</p>
+<table>
+<tr><th>Level</th><th>Percent</th></tr>
+{{range .Duplication.Levels}}<tr><td>{{.Level}}</td><td>{{printf "%.2f" .Percent}}</td></tr>
+{{end}}</table>
+<h2>Overrepresented sequences</h2>
+<table>
+<tr><th>Sequence</th><th>Count</th><th>Percent</th></tr>
+{{range .Overrepresented}}<tr><td>{{.Sequence}}</td><td>{{.Count}}</td><td>{{printf "%.2f" .Percent}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`
+
+var (
+	textTemplate = text.Must(text.New("text").Parse(textReport))
+	htmlTemplate = html.Must(html.New("html").Parse(htmlReport))
+)
+
+// WriteText writes the Report as plain text with tab-separated tables
+func (r *Report) WriteText(w io.Writer) error {
+	return textTemplate.Execute(w, r)
+}
+
+// WriteHTML writes the Report as an HTML page of tables
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
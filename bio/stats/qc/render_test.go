@@ -0,0 +1,36 @@
+package qc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sembio/go/bio/io/fastq"
+	"github.com/sembio/go/bio/sequence/immutable"
+)
+
+func TestWriteText(t *testing.T) {
+	r := collect(t, fastq.New("r1", "II#", immutable.New("ACG")))
+	out := new(strings.Builder)
+	if err := r.WriteText(out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Reads\t1\n", "## Per position quality", "1\t40.00\t40\t40\t40\t40\t40\n", "ACG\t1\t100.00\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Want: %q in\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := collect(t, fastq.New("r1", "II#", immutable.New("ACG")))
+	r.Encoding = "<script>"
+	out := new(strings.Builder)
+	if err := r.WriteHTML(out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<h2>Per position quality</h2>", "<td>ACG</td><td>1</td><td>100.00</td>", "&lt;script&gt;"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Want: %q in\n%s", want, out.String())
+		}
+	}
+}
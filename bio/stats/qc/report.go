@@ -0,0 +1,238 @@
+package qc
+
+import (
+	"math"
+	"sort"
+)
+
+// Report is the summary of every read added to a Collector.
+// Positions are counted from 1 and percentages are from 0 to 100.
+type Report struct {
+	Reads uint `json:"reads"`
+	Bases uint `json:"bases"`
+
+	// Encoding is the quality encoding, empty if no reads had qualities
+	Encoding string `json:"encoding,omitempty"`
+
+	// GC is the GC content of all bases known to be A, C, G, T or U
+	GC float64 `json:"gc_percent"`
+
+	PositionQuality []PositionQuality `json:"per_position_quality"`
+
+	// SequenceQuality counts reads by their mean Phred score (rounded)
+	SequenceQuality []Count `json:"per_sequence_quality"`
+
+	BaseContent []BaseContent `json:"per_base_content"`
+
+	// GCDistribution counts reads by their GC content (rounded)
+	GCDistribution []Count `json:"gc_distribution"`
+
+	// NContent is the percentage of N bases at each position
+	NContent []float64 `json:"n_content"`
+
+	// Lengths counts reads by their length
+	Lengths []Count `json:"length_distribution"`
+
+	Duplication     Duplication `json:"duplication"`
+	Overrepresented []Sequence  `json:"overrepresented"`
+}
+
+// Count is how many times a value was seen
+type Count struct {
+	Value int  `json:"value"`
+	Count uint `json:"count"`
+}
+
+// PositionQuality summarizes the Phred scores at a position of the reads
+type PositionQuality struct {
+	Position      int     `json:"position"`
+	Mean          float64 `json:"mean"`
+	Median        float64 `json:"median"`
+	LowerQuartile float64 `json:"lower_quartile"`
+	UpperQuartile float64 `json:"upper_quartile"`
+	Percentile10  float64 `json:"percentile_10"`
+	Percentile90  float64 `json:"percentile_90"`
+}
+
+// BaseContent is the percentage of each base at a position of the reads
+type BaseContent struct {
+	Position int     `json:"position"`
+	A        float64 `json:"a"`
+	C        float64 `json:"c"`
+	G        float64 `json:"g"`
+	T        float64 `json:"t"`
+	N        float64 `json:"n"`
+}
+
+// Duplication summarizes how often sequences were seen more than once.
+// Sequences longer than 75 bases are compared by their first 50 bases.
+type Duplication struct {
+	// Remaining is the percentage of reads left if duplicates were removed
+	Remaining float64 `json:"remaining_percent"`
+
+	Levels []Level `json:"levels"`
+}
+
+// Level is the percentage of reads whose sequence was seen a number of times
+type Level struct {
+	Level   string  `json:"level"`
+	Percent float64 `json:"percent"`
+}
+
+// Sequence is a sequence that makes up more than its share of the reads
+type Sequence struct {
+	Sequence string  `json:"sequence"`
+	Count    uint    `json:"count"`
+	Percent  float64 `json:"percent"`
+}
+
+// levels are the lowest number of times sequences at each duplication level were seen
+var levels = []struct {
+	min  uint
+	name string
+}{
+	{1, "1"}, {2, "2"}, {3, "3"}, {4, "4"}, {5, "5"}, {6, "6"}, {7, "7"}, {8, "8"}, {9, "9"},
+	{10, "10+"}, {50, "50+"}, {100, "100+"}, {500, "500+"}, {1000, "1k+"}, {5000, "5k+"}, {10000, "10k+"},
+}
+
+// Report summarizes the reads added so far
+func (c *Collector) Report() *Report {
+	r := &Report{
+		Reads:           c.reads,
+		Bases:           c.bases,
+		GC:              percent(c.gc, c.acgt),
+		PositionQuality: make([]PositionQuality, len(c.positionQuality)),
+		SequenceQuality: counts(c.sequenceQuality[:]),
+		BaseContent:     make([]BaseContent, len(c.composition)),
+		GCDistribution:  counts(c.gcDistribution[:]),
+		NContent:        make([]float64, len(c.composition)),
+		Lengths:         make([]Count, 0, len(c.lengths)),
+		Overrepresented: make([]Sequence, 0),
+	}
+	if c.qualities != 0 {
+		r.Encoding = c.enc.String()
+	}
+
+	for i, hist := range c.positionQuality {
+		r.PositionQuality[i] = PositionQuality{
+			Position:      i + 1,
+			Mean:          mean(hist[:]),
+			Median:        percentile(hist[:], 0.5),
+			LowerQuartile: percentile(hist[:], 0.25),
+			UpperQuartile: percentile(hist[:], 0.75),
+			Percentile10:  percentile(hist[:], 0.1),
+			Percentile90:  percentile(hist[:], 0.9),
+		}
+	}
+
+	for i, comp := range c.composition {
+		total := uint(0)
+		for _, n := range comp {
+			total += n
+		}
+		r.BaseContent[i] = BaseContent{
+			Position: i + 1,
+			A:        percent(comp['A'], total),
+			C:        percent(comp['C'], total),
+			G:        percent(comp['G'], total),
+			T:        percent(comp['T']+comp['U'], total),
+			N:        percent(comp['N'], total),
+		}
+		r.NContent[i] = r.BaseContent[i].N
+	}
+
+	for length, n := range c.lengths {
+		r.Lengths = append(r.Lengths, Count{Value: length, Count: n})
+	}
+	sort.Slice(r.Lengths, func(i, j int) bool {
+		return r.Lengths[i].Value < r.Lengths[j].Value
+	})
+
+	r.Duplication = c.duplication()
+	for seq, n := range c.tracked {
+		if c.reads != 0 && float64(n)/float64(c.reads) > Overrepresented {
+			r.Overrepresented = append(r.Overrepresented, Sequence{
+				Sequence: seq,
+				Count:    n,
+				Percent:  percent(n, c.reads),
+			})
+		}
+	}
+	sort.Slice(r.Overrepresented, func(i, j int) bool {
+		a, b := r.Overrepresented[i], r.Overrepresented[j]
+		return a.Count > b.Count || (a.Count == b.Count && a.Sequence < b.Sequence)
+	})
+	return r
+}
+
+// duplication summarizes the duplication levels of the tracked sequences
+func (c *Collector) duplication() Duplication {
+	d := Duplication{
+		Levels: make([]Level, len(levels)),
+	}
+	reads := make([]uint, len(levels))
+	for _, n := range c.tracked {
+		k := len(levels) - 1
+		for levels[k].min > n {
+			k--
+		}
+		reads[k] += n
+	}
+	for k, l := range levels {
+		d.Levels[k] = Level{Level: l.name, Percent: percent(reads[k], c.trackedReads)}
+	}
+	d.Remaining = percent(uint(len(c.tracked)), c.trackedReads)
+	return d
+}
+
+// counts lists the non-zero counts of a histogram
+func counts(hist []uint) []Count {
+	cs := make([]Count, 0)
+	for v, n := range hist {
+		if n != 0 {
+			cs = append(cs, Count{Value: v, Count: n})
+		}
+	}
+	return cs
+}
+
+// mean is the mean score of a histogram of scores
+func mean(hist []uint) float64 {
+	sum, total := 0.0, uint(0)
+	for v, n := range hist {
+		sum += float64(v) * float64(n)
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / float64(total)
+}
+
+// percentile is the nearest-rank percentile p of a histogram of scores
+func percentile(hist []uint, p float64) float64 {
+	total := uint(0)
+	for _, n := range hist {
+		total += n
+	}
+	rank := uint(math.Ceil(p * float64(total)))
+	if rank == 0 {
+		rank = 1
+	}
+	seen := uint(0)
+	for v, n := range hist {
+		seen += n
+		if seen >= rank {
+			return float64(v)
+		}
+	}
+	return 0
+}
+
+// percent is n as a percentage of total (0 if total is 0)
+func percent(n, total uint) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(total)
+}
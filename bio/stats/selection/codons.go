@@ -0,0 +1,66 @@
+package selection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/utils"
+)
+
+const nucleotides = "ACGT"
+
+// lookup is a codon table resolved for all 64 codons with stop codons as '*'
+type lookup map[string]byte
+
+func newLookup(t codon.Interface) lookup {
+	l := make(lookup, 64)
+	for _, c := range codon.Triplets() {
+		if utils.InStrings(c, t.StopCodons()) {
+			l[c] = '*'
+		} else if aa, ok := t.Translate(c); ok {
+			l[c] = aa
+		}
+	}
+	return l
+}
+
+// stop reports whether c is a stop codon (or not in the table at all)
+func (l lookup) stop(c string) bool {
+	aa, ok := l[c]
+	return !ok || aa == '*'
+}
+
+// mutate is c with position i replaced by nucleotide b
+func mutate(c string, i int, b byte) string {
+	m := []byte(c)
+	m[i] = b
+	return string(m)
+}
+
+// pairs splits two aligned sequences into codon pairs (in frame 1), skipping
+// any pair with a letter other than A, C, G, T (such as gaps) or a stop codon.
+func pairs(a, b sequence.Interface, l lookup) ([][2]string, error) {
+	if a.Length() != b.Length() {
+		return nil, fmt.Errorf("aligned sequences differ in length [%d != %d]", a.Length(), b.Length())
+	}
+	sa, err := a.Range(0, a.Length())
+	if err != nil {
+		return nil, err
+	}
+	sb, err := b.Range(0, b.Length())
+	if err != nil {
+		return nil, err
+	}
+	sa, sb = strings.ToUpper(sa), strings.ToUpper(sb)
+	ps := make([][2]string, 0, len(sa)/3)
+	for i := 0; i+3 <= len(sa); i += 3 {
+		ca, cb := sa[i:i+3], sb[i:i+3]
+		if strings.Trim(ca+cb, nucleotides) != "" || l.stop(ca) || l.stop(cb) {
+			continue
+		}
+		ps = append(ps, [2]string{ca, cb})
+	}
+	return ps, nil
+}
@@ -0,0 +1,12 @@
+/*
+Package selection estimates selective pressure on aligned coding sequences
+by comparing nonsynonymous (dN) and synonymous (dS) divergence.
+
+Two estimators are provided:
+
+	NeiGojobori: Nei and Gojobori (1986), doi:10.1093/oxfordjournals.molbev.a040410
+	LWL85: Li, Wu, and Luo (1985), doi:10.1093/oxfordjournals.molbev.a040343
+
+Both respect the given codon table as synonymous sites differ between tables.
+*/
+package selection
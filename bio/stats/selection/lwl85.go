@@ -0,0 +1,113 @@
+package selection
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// degeneracy classes of a codon position
+const (
+	nondegenerate = iota // no change is synonymous
+	twofold              // one or two changes are synonymous
+	fourfold             // every change is synonymous
+)
+
+// degeneracy is the class of position i in codon c
+func (l lookup) degeneracy(c string, i int) int {
+	syn := 0
+	for j := range nucleotides {
+		if b := nucleotides[j]; b != c[i] && l[mutate(c, i, b)] == l[c] {
+			syn++
+		}
+	}
+	switch syn {
+	case 0:
+		return nondegenerate
+	case 3:
+		return fourfold
+	default:
+		return twofold
+	}
+}
+
+// transition reports whether a change from a to b is a purine-purine or
+// pyrimidine-pyrimidine change
+func transition(a, b byte) bool {
+	switch string([]byte{a, b}) {
+	case "AG", "GA", "CT", "TC":
+		return true
+	default:
+		return false
+	}
+}
+
+// kimura is the pair (A, B) of transitional and transversional substitutions
+// per site given proportions of transitions p and transversions q (Kimura, 1980)
+func kimura(p, q float64) (float64, float64) {
+	if 1-2*p-q <= 0 || 1-2*q <= 0 {
+		return math.NaN(), math.NaN()
+	}
+	a := 1 / (1 - 2*p - q)
+	b := 1 / (1 - 2*q)
+	return 0.5*math.Log(a) - 0.25*math.Log(b), 0.5 * math.Log(b)
+}
+
+// LWL85 estimates dN and dS between two aligned coding sequences
+// by the method of Li, Wu, and Luo (1985).
+// Each codon position is classed as nondegenerate, twofold, or fourfold degenerate
+// under the codon table and each differing position is classed by its degeneracy
+// averaged over both codons. Transitions and transversions within each class are
+// corrected with Kimura's two-parameter model.
+// The sequences are read in frame 1 and codons where either sequence has a letter
+// other than A, C, G, T (such as a gap) or a stop codon are skipped.
+// If the differences are too many to correct for, the Estimate is returned with
+// NaN distances along with an error.
+func LWL85(a, b sequence.Interface, t codon.Interface) (Estimate, error) {
+	l := newLookup(t)
+	ps, err := pairs(a, b, l)
+	if err != nil {
+		return Estimate{}, err
+	}
+	var sites, ts, tv [3]float64
+	for _, p := range ps {
+		for i := 0; i < 3; i++ {
+			da, db := l.degeneracy(p[0], i), l.degeneracy(p[1], i)
+			sites[da] += 0.5
+			sites[db] += 0.5
+			if p[0][i] == p[1][i] {
+				continue
+			}
+			if transition(p[0][i], p[1][i]) {
+				ts[da] += 0.5
+				ts[db] += 0.5
+			} else {
+				tv[da] += 0.5
+				tv[db] += 0.5
+			}
+		}
+	}
+
+	var as, bs [3]float64
+	for i := range sites {
+		if sites[i] != 0 {
+			as[i], bs[i] = kimura(ts[i]/sites[i], tv[i]/sites[i])
+		}
+	}
+	l0, l2, l4 := sites[nondegenerate], sites[twofold], sites[fourfold]
+	e := Estimate{
+		N: 2*l2/3 + l0,
+		S: l2/3 + l4,
+	}
+	if e.S == 0 || e.N == 0 {
+		return e, fmt.Errorf("no sites to compare [S=%g, N=%g]", e.S, e.N)
+	}
+	e.DS = 3 * (l2*as[twofold] + l4*(as[fourfold]+bs[fourfold])) / (l2 + 3*l4)
+	e.DN = 3 * (l2*bs[twofold] + l0*(as[nondegenerate]+bs[nondegenerate])) / (2*l2 + 3*l0)
+	if math.IsNaN(e.DS) || math.IsNaN(e.DN) {
+		return e, fmt.Errorf("too many differences to correct")
+	}
+	return e, nil
+}
@@ -0,0 +1,73 @@
+package selection_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/selection"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestLWL85(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Identical sequences have no divergence",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				seq, _ := immutable.NewDna(s)
+				e, err := selection.LWL85(seq, seq, codon.Standard{})
+				return err == nil && e.DN == 0 && e.DS == 0
+			},
+			gen.UIntRange(30, sequence.TestableLength),
+		),
+	)
+	properties.TestingRun(t)
+
+	t.Run("Fourfold change only has no dN", func(t *testing.T) {
+		a, _ := immutable.NewDna("CTGAAAGGGCCCTTTACG")
+		b, _ := immutable.NewDna("CTGAAAGGACCCTTTACG")
+		e, err := selection.LWL85(a, b, codon.Standard{})
+		if err != nil || e.DN != 0 || e.DS <= 0 {
+			t.Errorf("Want: dN = 0 and dS > 0, Got: %+v %v", e, err)
+		}
+	})
+	t.Run("Nondegenerate change only has no dS", func(t *testing.T) {
+		a, _ := immutable.NewDna("CTGAAAGGGCCCTTTACG")
+		b, _ := immutable.NewDna("CTGAAAGGGCCCTTTGCG")
+		e, err := selection.LWL85(a, b, codon.Standard{})
+		if err != nil || e.DS != 0 || e.DN <= 0 {
+			t.Errorf("Want: dS = 0 and dN > 0, Got: %+v %v", e, err)
+		}
+	})
+	t.Run("Sites partition every position", func(t *testing.T) {
+		a, _ := immutable.NewDna("CTGAAAGGGCCCTTTACG")
+		e, _ := selection.LWL85(a, a, codon.Standard{})
+		syn, nonsyn, _ := selection.Sites(a, codon.Standard{})
+		if e.N+e.S > syn+nonsyn {
+			t.Errorf("LWL85 has more sites than positions: %v > %v", e.N+e.S, syn+nonsyn)
+		}
+	})
+}
+
+func ExampleLWL85() {
+	a, _ := immutable.NewDna("ATGCTGAAAGGGCCCTTTACGGATTGCAAACGTATTGAGTCAGCCTGGTAC")
+	b, _ := immutable.NewDna("ATGCTAAAAGGGCCCTTTACCGATTGCAAGCATATTGAGTCAGCCTGGTAC")
+	e, err := selection.LWL85(a, b, codon.Standard{})
+
+	fmt.Printf("dN=%.4f dS=%.4f %v", e.DN, e.DS, err)
+	// Output:
+	// dN=0.0249 dS=0.3861 <nil>
+}
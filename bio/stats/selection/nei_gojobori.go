@@ -0,0 +1,150 @@
+package selection
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+)
+
+// Estimate is an estimate of divergence at nonsynonymous and synonymous sites
+type Estimate struct {
+	// N is the number of nonsynonymous sites
+	N float64
+	// S is the number of synonymous sites
+	S float64
+	// DN is the number of nonsynonymous substitutions per nonsynonymous site
+	DN float64
+	// DS is the number of synonymous substitutions per synonymous site
+	DS float64
+}
+
+// Omega is the ratio dN/dS
+func (e Estimate) Omega() float64 {
+	return e.DN / e.DS
+}
+
+// Sites counts the synonymous and nonsynonymous sites of s (read in frame 1)
+// by the method of Nei and Gojobori (1986): each position of a codon contributes
+// the fraction of its three possible changes that are synonymous.
+// Codons with letters other than A, C, G, T and stop codons are skipped.
+func Sites(s sequence.Interface, t codon.Interface) (syn, nonsyn float64, err error) {
+	l := newLookup(t)
+	ps, err := pairs(s, s, l)
+	for _, p := range ps {
+		cs := l.sites(p[0])
+		syn += cs
+		nonsyn += 3 - cs
+	}
+	return syn, nonsyn, err
+}
+
+// sites is the number of synonymous sites of codon c
+func (l lookup) sites(c string) float64 {
+	syn := 0.0
+	for i := range c {
+		for j := range nucleotides {
+			if b := nucleotides[j]; b != c[i] && l[mutate(c, i, b)] == l[c] {
+				syn += 1.0 / 3
+			}
+		}
+	}
+	return syn
+}
+
+// differences is the number of synonymous and nonsynonymous differences between
+// codons a and b averaged over every pathway of single changes that avoids stop codons
+func (l lookup) differences(a, b string) (syn, nonsyn float64) {
+	diff := make([]int, 0, 3)
+	for i := range a {
+		if a[i] != b[i] {
+			diff = append(diff, i)
+		}
+	}
+	paths := 0
+	for _, order := range permutations(diff) {
+		cur := a
+		pathSyn, pathNonsyn, valid := 0.0, 0.0, true
+		for _, i := range order {
+			next := mutate(cur, i, b[i])
+			if l.stop(next) {
+				valid = false
+				break
+			}
+			if l[next] == l[cur] {
+				pathSyn++
+			} else {
+				pathNonsyn++
+			}
+			cur = next
+		}
+		if valid {
+			syn += pathSyn
+			nonsyn += pathNonsyn
+			paths++
+		}
+	}
+	if paths == 0 {
+		return 0, 0
+	}
+	return syn / float64(paths), nonsyn / float64(paths)
+}
+
+// permutations lists every ordering of the positions in ps
+func permutations(ps []int) [][]int {
+	if len(ps) <= 1 {
+		return [][]int{ps}
+	}
+	perms := make([][]int, 0)
+	for i := range ps {
+		rest := make([]int, 0, len(ps)-1)
+		rest = append(rest, ps[:i]...)
+		rest = append(rest, ps[i+1:]...)
+		for _, p := range permutations(rest) {
+			perms = append(perms, append([]int{ps[i]}, p...))
+		}
+	}
+	return perms
+}
+
+// jukesCantor corrects a proportion of differences p for multiple substitutions
+func jukesCantor(p float64) float64 {
+	if p >= 0.75 {
+		return math.NaN()
+	}
+	return -0.75 * math.Log(1-4*p/3)
+}
+
+// NeiGojobori estimates dN and dS between two aligned coding sequences
+// by the method of Nei and Gojobori (1986) with Jukes-Cantor correction.
+// The sequences are read in frame 1 and codons where either sequence has a letter
+// other than A, C, G, T (such as a gap) or a stop codon are skipped.
+// If the differences are too many to correct for, the Estimate is returned with
+// NaN distances along with an error.
+func NeiGojobori(a, b sequence.Interface, t codon.Interface) (Estimate, error) {
+	l := newLookup(t)
+	ps, err := pairs(a, b, l)
+	if err != nil {
+		return Estimate{}, err
+	}
+	var e Estimate
+	sd, nd := 0.0, 0.0
+	for _, p := range ps {
+		sa, sb := l.sites(p[0]), l.sites(p[1])
+		e.S += (sa + sb) / 2
+		e.N += (6 - sa - sb) / 2
+		syn, nonsyn := l.differences(p[0], p[1])
+		sd += syn
+		nd += nonsyn
+	}
+	if e.S == 0 || e.N == 0 {
+		return e, fmt.Errorf("no sites to compare [S=%g, N=%g]", e.S, e.N)
+	}
+	e.DS = jukesCantor(sd / e.S)
+	e.DN = jukesCantor(nd / e.N)
+	if math.IsNaN(e.DS) || math.IsNaN(e.DN) {
+		return e, fmt.Errorf("too many differences to correct [pS=%g, pN=%g]", sd/e.S, nd/e.N)
+	}
+	return e, nil
+}
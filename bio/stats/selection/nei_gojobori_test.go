@@ -0,0 +1,118 @@
+package selection_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+	"github.com/sembio/go/bio/alphabet/hashmap"
+	"github.com/sembio/go/bio/data/codon"
+	"github.com/sembio/go/bio/sequence"
+	"github.com/sembio/go/bio/sequence/immutable"
+	"github.com/sembio/go/bio/stats/selection"
+	"github.com/sembio/go/bio/test"
+)
+
+func TestSites(t *testing.T) {
+	tt := []struct {
+		codon string
+		syn   float64
+	}{
+		{"TTA", 2.0 / 3},
+		{"CTA", 4.0 / 3},
+		{"ATG", 0},
+		{"GGG", 1},
+	}
+	for _, tc := range tt {
+		t.Run(tc.codon, func(t *testing.T) {
+			seq, _ := immutable.NewDna(tc.codon)
+			syn, nonsyn, err := selection.Sites(seq, codon.Standard{})
+			if err != nil || math.Abs(syn-tc.syn) > 1e-9 || math.Abs(syn+nonsyn-3) > 1e-9 {
+				t.Errorf("Want: %v %v, Got: %v %v %v", tc.syn, 3-tc.syn, syn, nonsyn, err)
+			}
+		})
+	}
+}
+
+func TestNeiGojobori(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(test.Seed)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Identical sequences have no divergence",
+		prop.ForAll(
+			func(n uint) bool {
+				s := test.RandomStringFromRunes(
+					test.Seed,
+					n,
+					[]rune(hashmap.NewDna().String()),
+				)
+				seq, _ := immutable.NewDna(s)
+				e, err := selection.NeiGojobori(seq, seq, codon.Standard{})
+				return err == nil && e.DN == 0 && e.DS == 0
+			},
+			gen.UIntRange(30, sequence.TestableLength),
+		),
+	)
+	properties.TestingRun(t)
+
+	t.Run("Synonymous change only has no dN", func(t *testing.T) {
+		a, _ := immutable.NewDna("CTGAAAGGGCCCTTTACG")
+		b, _ := immutable.NewDna("CTAAAAGGGCCCTTTACG")
+		e, err := selection.NeiGojobori(a, b, codon.Standard{})
+		if err != nil || e.DN != 0 || e.DS <= 0 {
+			t.Errorf("Want: dN = 0 and dS > 0, Got: %+v %v", e, err)
+		}
+	})
+	t.Run("Nonsynonymous change only has no dS", func(t *testing.T) {
+		a, _ := immutable.NewDna("CTGAAAGGGCCCTTTACG")
+		b, _ := immutable.NewDna("CTGAGAGGGCCCTTTACG")
+		e, err := selection.NeiGojobori(a, b, codon.Standard{})
+		if err != nil || e.DS != 0 || e.DN <= 0 {
+			t.Errorf("Want: dS = 0 and dN > 0, Got: %+v %v", e, err)
+		}
+	})
+	t.Run("Gapped codons are skipped", func(t *testing.T) {
+		a, _ := immutable.NewDnaIupac("CTGAAA---CCCTTTACG")
+		b, _ := immutable.NewDnaIupac("CTGAAAGGGCCCTTTACG")
+		e, err := selection.NeiGojobori(a, b, codon.Standard{})
+		if err != nil || math.Abs(e.N+e.S-15) > 1e-9 {
+			t.Errorf("Want: 15 sites, Got: %v %v", e.N+e.S, err)
+		}
+	})
+	t.Run("Codon table is respected", func(t *testing.T) {
+		a, _ := immutable.NewDna("TGGAAAGGGCCCTTTACG")
+		b, _ := immutable.NewDna("TGAAAAGGGCCCTTTACG")
+		std, _ := selection.NeiGojobori(a, b, codon.Standard{})
+		mt, _ := selection.NeiGojobori(a, b, codon.VertebrateMt{})
+		if std.DS != 0 || mt.DS <= 0 || mt.DN != 0 {
+			t.Errorf("TGG->TGA should be skipped as a stop in Standard and synonymous in VertebrateMt")
+		}
+	})
+	t.Run("Different lengths errors", func(t *testing.T) {
+		a, _ := immutable.NewDna("TGGAAA")
+		b, _ := immutable.NewDna("TGG")
+		if _, err := selection.NeiGojobori(a, b, codon.Standard{}); err == nil {
+			t.Error("sequences of different lengths should error")
+		}
+	})
+	t.Run("Saturated differences errors", func(t *testing.T) {
+		a, _ := immutable.NewDna("TTA")
+		b, _ := immutable.NewDna("CTA")
+		if _, err := selection.NeiGojobori(a, b, codon.Standard{}); err == nil {
+			t.Error("pS of 1 should error")
+		}
+	})
+}
+
+func ExampleNeiGojobori() {
+	a, _ := immutable.NewDna("ATGCTGAAAGGGCCCTTTACGGATTGCAAACGTATTGAGTCAGCCTGGTAC")
+	b, _ := immutable.NewDna("ATGCTAAAAGGGCCCTTTACCGATTGCAAGCATATTGAGTCAGCCTGGTAC")
+	e, err := selection.NeiGojobori(a, b, codon.Standard{})
+
+	fmt.Printf("dN=%.4f dS=%.4f %v", e.DN, e.DS, err)
+	// Output:
+	// dN=0.0248 dS=0.3831 <nil>
+}
@@ -7,12 +7,24 @@ import (
 	"math/rand"
 	"reflect"
 	"testing"
+
+	"github.com/sembio/go/bio/alphabet/hashmap"
 )
 
 // Seed is a chosen value that should be used to
 // seed pseudorandom number generators
 const Seed int64 = 1234
 
+// RevComp is the reverse complement of IUPAC DNA s
+func RevComp(s string) string {
+	a := hashmap.NewDnaIupac()
+	rc := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		rc[len(s)-1-i] = a.Complement(string(s[i]))[0]
+	}
+	return string(rc)
+}
+
 // DeepClone does a deep copy from one src to one dest
 // Note: DeepClone copies only the public parts of a struct
 func DeepClone(src, dest interface{}) {